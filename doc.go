@@ -59,6 +59,165 @@ Output:
 
 	YYYY/MM/DD HH:MM:SS INFO 2:
 
+-- Configure output per logger ------------------------------------------------
+
+Instead of sharing Go's standard logger, a message logger can be given
+its own destination, prefix, and flags.
+Example:
+
+	import "github.com/senzing/go-logging/logger"
+
+	auditFile, _ := os.Create("audit.log")
+	auditLogger, _ := messagelogger.New(auditFile, logger.Prefix("audit: "), logger.Flags(log.LstdFlags))
+	consoleLogger, _ := messagelogger.New(os.Stderr, logger.Flags(0))
+	auditLogger.Log(3)
+	consoleLogger.Log(3)
+
+Output in audit.log:
+
+	audit: YYYY/MM/DD HH:MM:SS INFO 3:
+
+Output on stderr:
+
+	INFO 3:
+
+-- Configure from environment variables ---------------------------------------
+
+messagelogger.NewFromEnvironment() creates a message logger configured by
+SENZING_TOOLS_LOG_LEVEL, SENZING_TOOLS_LOG_FORMAT, SENZING_TOOLS_LOG_OUTPUT,
+SENZING_TOOLS_LOG_LOCATION, and SENZING_TOOLS_LOG_CALLER_SKIP.
+Parameters override the environment.
+Example:
+
+	export SENZING_TOOLS_LOG_LEVEL=DEBUG
+	export SENZING_TOOLS_LOG_FORMAT=json
+	export SENZING_TOOLS_LOG_OUTPUT=/var/log/senzing/loader.log
+
+	messageLogger, err := messagelogger.NewFromEnvironment(messageText)
+	if err != nil {
+		log.Fatal(err)
+	}
+	messageLogger.Log(2001)
+
+Output in /var/log/senzing/loader.log:
+
+	YYYY/MM/DD HH:MM:SS {"level":"INFO","id":"2001"}
+
+-- Write to rotating log files ------------------------------------------------
+
+A logfile.LogFile is an io.Writer that rotates its file by size or by time.
+Example:
+
+	logFile := &logfile.LogFile{
+		Filename:   "/var/log/senzing/loader.log",
+		MaxSize:    100 * 1024 * 1024,
+		MaxBackups: 7,
+		Compress:   true,
+	}
+	defer logFile.Close()
+	logFile.ReopenOnSignal()
+	messageLogger, _ := messagelogger.New(logFile)
+
+-- Send messages to syslog ----------------------------------------------------
+
+messageformat.MessageFormatSyslog creates RFC 5424 messages.
+The level sets the severity, the id is the MSGID, and the details are structured data.
+The syslogsink package sends them over a unix datagram socket, UDP, or TCP.
+Example:
+
+	writer, _ := syslogsink.Dial("unixgram", "")
+	defer writer.Close()
+	messageLogger, _ := messagelogger.New(messagelogger.NewWriterSink(writer, &messageformat.MessageFormatSyslog{AppName: "loader"}))
+
+-- Send messages to journald --------------------------------------------------
+
+messageformat.MessageFormatJournald creates journal entries with MESSAGE, PRIORITY, MESSAGE_ID,
+CODE_FILE, CODE_LINE, CODE_FUNC, and one DETAIL_XXXX field per detail.
+The journaldsink package sends them to the journald socket.
+Example:
+
+	writer, _ := journaldsink.Dial("")
+	defer writer.Close()
+	messageLogger, _ := messagelogger.New(messagelogger.NewWriterSink(writer, &messageformat.MessageFormatJournald{}))
+
+-- Send messages to Graylog ---------------------------------------------------
+
+messageformat.MessageFormatGelf creates GELF 1.1 messages with one additional field per detail.
+The gelfsink package sends them over UDP, in chunks and optionally compressed, or over TCP.
+Example:
+
+	writer, _ := gelfsink.Dial("udp", "graylog:12201")
+	defer writer.Close()
+	writer.Compress = true
+	messageLogger, _ := messagelogger.New(messagelogger.NewWriterSink(writer, &messageformat.MessageFormatGelf{}))
+
+-- Send messages to an OpenTelemetry collector --------------------------------
+
+messageformat.MessageFormatOtlp creates OpenTelemetry log records.
+The otlpsink package sends them to a collector over OTLP/HTTP in batches, retrying failed requests with backoff.
+Close() sends the records still waiting.
+Example:
+
+	writer, _ := otlpsink.New("http://localhost:4318/v1/logs")
+	defer writer.Close()
+	writer.Resource = map[string]string{"service.name": "loader"}
+	messageLogger, _ := messagelogger.New(messagelogger.NewWriterSink(writer, &messageformat.MessageFormatOtlp{}))
+
+-- Send messages to several destinations --------------------------------------
+
+A message logger can send each message to several sinks.
+Each sink has its own minimum level and its own message format.
+Example:
+
+	consoleSink := &messagelogger.MessageSink{
+		Logger:        logger.NewWithOutput(os.Stderr, "", 0).SetLogLevel(logger.LevelInfo),
+		MessageFormat: &messageformat.MessageFormatDefault{},
+	}
+	fileSink := &messagelogger.MessageSink{
+		Logger:        logger.NewWithOutput(logFile, "", 0).SetLogLevel(logger.LevelTrace),
+		MessageFormat: &messageformat.MessageFormatSenzing{},
+	}
+	messageLogger, _ = messagelogger.New(consoleSink, fileSink, logger.LevelTrace)
+
+-- Log asynchronously ---------------------------------------------------------
+
+An *Async parameter makes Log() queue messages for a background writer.
+When the queue is full, Log() waits, drops the new message, or drops the oldest queued message.
+FATAL and PANIC messages are written after the queued messages.
+Flush(), Close(), and Dropped() are methods of messagelogger.MessageLoggerAsyncInterface.
+Example:
+
+	messageLogger, _ := messagelogger.New(&messagelogger.Async{
+		QueueSize:      10000,
+		OverflowPolicy: messagelogger.OverflowDropOldest,
+	})
+	defer messageLogger.(messagelogger.MessageLoggerAsyncInterface).Close()
+
+-- Load messages from a file --------------------------------------------------
+
+Message templates, statuses, and level overrides can be kept in a JSON or YAML catalog.
+The catalog is validated when it is loaded.
+Example:
+
+	import "github.com/senzing/go-logging/messagecatalog"
+
+	messageCatalog, err := messagecatalog.Load("messages.yaml")
+	messageLogger, _ = messagelogger.NewSenzingLogger(9999, messageCatalog.IdMessages, messageCatalog.MessageStatus(), messageCatalog.MessageLevel())
+
+-- Bridge to log/slog ---------------------------------------------------------
+
+A message logger can write into any slog.Handler (https://pkg.go.dev/log/slog#Handler).
+Example:
+
+	messageLogger, _ = messagelogger.New(slog.NewJSONHandler(os.Stderr, nil))
+
+In the other direction, a slog.Logger can route its records through a message logger.
+The record's attributes become "details".
+Example:
+
+	slogLogger := slog.New(messagelogger.NewSlogHandler(messageLogger, 2000))
+	slogLogger.Info("A message", "jobId", 7)
+
 -- Customize the id field -----------------------------------------------------
 
 To create a unique identifier, not just an integer,
@@ -96,6 +255,65 @@ Output:
 The fields submitted in the Log() call are seen in a map in the log message.
 They will be listed in the order specified in the Log() call.
 
+To give a field a key that does not depend on its position, name it.
+Example:
+
+	messageLogger.Log(4, messagedetails.Pair("name", "Robert Smith"), messagedetails.Pairs("recordId", 12345))
+
+Output:
+
+	INFO senzing-99990004: [map[name:Robert Smith recordId:12345]]
+
+-- Add the same details to many messages --------------------------------------
+
+With() of messagelogger.MessageLoggerWithInterface returns a logger
+that places its details before the details of every message.
+The new logger shares the components and log level of the original.
+Example:
+
+	recordLogger := messageLogger.(messagelogger.MessageLoggerWithInterface).With(map[string]string{"dataSource": "CUSTOMERS", "recordId": "1001"})
+	recordLogger.Log(6, "Robert Smith")
+
+Output:
+
+	INFO senzing-99990006: [map[2:Robert Smith dataSource:CUSTOMERS recordId:1001]]
+
+-- Log values carried in a context --------------------------------------------
+
+Values stored in a context.Context, such as a request id, can be added to the details of every message.
+Register a ContextExtractor once, then use LogContext(), ErrorContext(), or MessageContext()
+of messagelogger.MessageLoggerContextInterface.
+Example:
+
+	messagelogger.RegisterContextExtractor(messagelogger.ContextValue(requestIdKey, "requestId"))
+	ctx := context.WithValue(context.Background(), requestIdKey, "abc-123")
+	contextLogger := messageLogger.(messagelogger.MessageLoggerContextInterface)
+	contextLogger.LogContext(ctx, 5, "Robert Smith")
+
+Output:
+
+	INFO senzing-99990005: [map[1:Robert Smith requestId:abc-123]]
+
+-- Correlate messages with traces ---------------------------------------------
+
+Messages can carry the trace and span identifiers of W3C trace context (https://www.w3.org/TR/trace-context/)
+in the "trace_id" and "span_id" fields of messageformat.MessageFormatSenzing and messageformat.MessageFormatJson.
+No collector is needed.
+Store the trace context in a context.Context and use LogContext(), ErrorContext(), or MessageContext(),
+or add the "traceparent" header of a request to the details.
+A "traceparent" value that is not valid stays in the "details" field.
+Example:
+
+	ctx := messagetrace.NewContext(context.Background(), traceContext)
+	contextLogger.LogContext(ctx, 2001, "Robert Smith")
+	messageLogger.Log(2001, "Robert Smith", messagetrace.TraceParent(request.Header.Get("traceparent")))
+
+Output:
+
+	{"level":"INFO","id":"senzing-99992001","text":"Robert Smith","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7","details":{"1":"Robert Smith"}}
+
+To use the spans of a tracing library, such as OpenTelemetry, see the messagetrace package.
+
 -- Adding a text field --------------------------------------------------------
 
 The additional information that is submitted in a Log() call can be used to create a text message.
@@ -157,6 +375,51 @@ Output:
 	WARN senzing-99993000: A test of WARN.
 	ERROR senzing-99994000: A test of ERROR.
 
+-- Set log levels by logger name ----------------------------------------------
+
+A messagelogger.Name parameter gives a message logger a dotted name.
+messagelogger.SetNameLogLevel() sets the level of the message loggers named by a prefix,
+with the most specific prefix winning.
+messagelogger.SetLogLevel() changes only the message loggers without a name level.
+Example:
+
+	messagelogger.SetLogLevel(messagelogger.LevelWarn)
+	messagelogger.SetNameLogLevel("senzing.g2engine", messagelogger.LevelTrace)
+	engineLogger, _ := messagelogger.New(messagelogger.Name("senzing.g2engine.addRecord"))
+	configLogger, _ := messagelogger.New(messagelogger.Name("senzing.g2config"))
+	engineLogger.Log(1)
+	configLogger.Log(1)
+
+Output:
+
+	INFO 1:
+
+Only engineLogger logs the message: it is at TRACE and configLogger is at WARN.
+
+-- Change the log level at runtime --------------------------------------------
+
+messagelogger.LevelHandler is an http.Handler that returns the system log level on GET
+and sets it, for every message logger, on PUT or POST.
+The "logger" query parameter selects one of its named message loggers instead.
+Example:
+
+	http.Handle("/loglevel", &messagelogger.LevelHandler{})
+
+Then:
+
+	curl -X PUT -d '{"level":"DEBUG"}' http://localhost:8080/loglevel
+
+Output:
+
+	{"level":"DEBUG"}
+
+On Unix, messagelogger.HandleLevelSignals() makes SIGUSR1 increase verbosity, e.g. INFO to DEBUG,
+and SIGUSR2 decrease it.
+Example:
+
+	stop, _ := messagelogger.HandleLevelSignals()
+	defer stop()
+
 -- Logging errors -------------------------------------------------------------
 
 Go errors can also be logged.
@@ -170,6 +433,16 @@ Output:
 
 	INFO senzing-99990010: Example errors. [map[1:error #1 2:error #2]]
 
+The error returned by Error() keeps the parts of the message,
+so failures can be routed on status and the errors passed in can be reached using errors.Is() and errors.As().
+Example:
+
+	err := messageLogger.Error(4001, err1)
+	var messageError *messagelogger.MessageError
+	if errors.As(err, &messageError) && messageError.Status == messagestatus.ErrorRetryable {
+		// Retry.
+	}
+
 -- Formatting -----------------------------------------------------------------
 
 The format of the log message can be modified by choosing a different message format.
@@ -184,5 +457,67 @@ Output:
 
 	{"level":"WARN","id":"senzing-99993000","text":"A test of WARN."}
 	{"level":"INFO","id":"senzing-99992011","text":"Robert Smith has a score of 12345.","details":{"1":"Robert Smith","2":12345,"3":"map[int]string{10:\"ten\", 20:\"twenty\"}"}}
+
+For logfmt (https://brandur.org/logfmt), use messageformat.MessageFormatLogfmt.
+Output:
+
+	level=WARN id=senzing-99993000 text="A test of WARN."
+	level=INFO id=senzing-99992011 text="Robert Smith has a score of 12345." errors.0.text="error #1" errors.1.text="error #2" details.1="Robert Smith" details.2=12345 details.3="map[int]string{10:\"ten\", 20:\"twenty\"}"
+
+For a layout of your own, use messageformat.MessageFormatTemplated with a text/template.
+Example:
+
+	messageFormat := &messageformat.MessageFormatTemplated{
+		Template: `{{.Level | pad 5}} {{.Id}}: {{.Text | truncate 60}} {{json .Details}}`,
+	}
+
+Output:
+
+	WARN  senzing-99993000: A test of WARN. null
+
+For reading messages in a terminal, use messageformat.MessageFormatConsole.
+The level is colored when the output is a terminal, unless the NO_COLOR environment variable is set.
+Output:
+
+	WARN  senzing-99993000: A test of WARN.
+	INFO  senzing-99992011: Robert Smith has a score of 12345.
+	    details:
+	        1: Robert Smith
+	        2: 12345
+
+For Elasticsearch, use messageformat.MessageFormatEcs for Elastic Common Schema (ECS) field names.
+Output:
+
+	{"@timestamp":"2000-01-01T00:00:00.000001Z","log.level":"WARN","message":"A test of WARN.","ecs.version":"8.11.0","event":{"code":"senzing-99993000"}}
+
+For an event bus, use messageformat.MessageFormatCloudEvents to wrap messages in CloudEvents 1.0 events.
+Output:
+
+	{"specversion":"1.0","id":"0b9e1a5c-9b1e-4a36-8f1e-3f2d1c0b9a87","source":"/loader","type":"com.senzing.log.senzing-99993000","datacontenttype":"application/json","time":"2000-01-01T00:00:00.000001Z","data":{"level":"WARN","id":"senzing-99993000","text":"A test of WARN."}}
+
+For compact messages read by programs, use messageformat.MessageFormatCbor or messageformat.MessageFormatMessagePack.
+They have the fields of messageformat.MessageFormatSenzing.
+messageformat.CborAsJson() and messageformat.MessagePackAsJson() convert them back to that JSON.
+A message logger writing them to an io.Writer leaves out the newline after each message,
+and messageformat.CborStreamAsJson() and messageformat.MessagePackStreamAsJson() read a file of them.
+Example:
+
+	message, _ := (&messageformat.MessageFormatCbor{}).Message("", "", "INFO", "", "senzing-99992001", "", "Bob", 0, nil, nil)
+	jsonMessage, _ := messageformat.CborAsJson(message)
+
+Output:
+
+	{"level":"INFO","id":"senzing-99992001","text":"Bob"}
+
+The JSON Schema of messages created by messageformat.MessageFormatSenzing and messageformat.MessageFormatJson
+is returned by messageformat.SenzingSchema().
+Its "version" is messageformat.SenzingFormatVersion.
+The major version changes when a field is removed or its type changes; the minor version changes when a field is added.
+To check a message, use messageformat.ValidateSenzing().
+Example:
+
+	if err := messageformat.ValidateSenzing(message); err != nil {
+		...
+	}
 */
 package main
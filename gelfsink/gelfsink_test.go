@@ -0,0 +1,180 @@
+package gelfsink
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/senzing/go-logging/logger"
+	"github.com/senzing/go-logging/messageformat"
+	"github.com/senzing/go-logging/messagelogger"
+	"github.com/stretchr/testify/assert"
+)
+
+var messageFormat = &messageformat.MessageFormatGelf{
+	Host: "host",
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+func testError(test *testing.T, err error) {
+	if err != nil {
+		assert.Fail(test, err.Error())
+	}
+}
+
+// Log a WARN and an INFO message through a message logger sending to writer.
+func testLog(test *testing.T, writer *Writer) {
+	messageLogger, err := messagelogger.New(messagelogger.NewWriterSink(writer, messageFormat))
+	testError(test, err)
+	testError(test, messageLogger.Log(3001, "Bob", logger.LevelWarn))
+	testError(test, messageLogger.Log(2001, strings.Repeat("Jane ", 100)))
+}
+
+func testAssertMessages(test *testing.T, messages []string) {
+	if assert.Len(test, messages, 2) {
+		var message map[string]interface{}
+		testError(test, json.Unmarshal([]byte(messages[0]), &message))
+		assert.Equal(test, "1.1", message["version"])
+		assert.Equal(test, "host", message["host"])
+		assert.Equal(test, "3001", message["short_message"])
+		assert.Equal(test, float64(4), message["level"])
+		assert.Equal(test, "3001", message["_message_id"])
+		assert.Equal(test, "Bob", message["_1"])
+		testError(test, json.Unmarshal([]byte(messages[1]), &message))
+		assert.Equal(test, float64(6), message["level"])
+		assert.Equal(test, strings.Repeat("Jane ", 100), message["_1"])
+	}
+}
+
+// Read datagrams until count messages are complete, joining chunks and decompressing.
+func testReadMessages(test *testing.T, conn net.PacketConn, count int) []string {
+	var result []string
+	chunks := map[string][][]byte{}
+	buffer := make([]byte, 65536)
+	for len(result) < count {
+		length, _, err := conn.ReadFrom(buffer)
+		if err != nil {
+			assert.Fail(test, err.Error())
+			break
+		}
+		datagram := append([]byte{}, buffer[:length]...)
+		if bytes.HasPrefix(datagram, []byte{0x1e, 0x0f}) {
+			messageId := string(datagram[2:10])
+			if chunks[messageId] == nil {
+				chunks[messageId] = make([][]byte, datagram[11])
+			}
+			chunks[messageId][datagram[10]] = datagram[12:]
+			complete := true
+			for _, chunk := range chunks[messageId] {
+				complete = complete && chunk != nil
+			}
+			if !complete {
+				continue
+			}
+			datagram = bytes.Join(chunks[messageId], nil)
+		}
+		if bytes.HasPrefix(datagram, []byte{0x1f, 0x8b}) {
+			reader, err := gzip.NewReader(bytes.NewReader(datagram))
+			testError(test, err)
+			datagram, err = io.ReadAll(reader)
+			testError(test, err)
+		}
+		result = append(result, string(datagram))
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestDialUnsupported(test *testing.T) {
+	_, err := Dial("unixgram", "/dev/log")
+	assert.Error(test, err)
+}
+
+func TestWriterUdp(test *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	testError(test, err)
+	defer listener.Close()
+	writer, err := Dial("udp", listener.LocalAddr().String())
+	testError(test, err)
+	defer writer.Close()
+	testLog(test, writer)
+	testAssertMessages(test, testReadMessages(test, listener, 2))
+}
+
+func TestWriterUdpChunked(test *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	testError(test, err)
+	defer listener.Close()
+	writer, err := Dial("udp", listener.LocalAddr().String())
+	testError(test, err)
+	defer writer.Close()
+	writer.ChunkSize = 100
+	testLog(test, writer)
+	testAssertMessages(test, testReadMessages(test, listener, 2))
+}
+
+func TestWriterUdpCompressed(test *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	testError(test, err)
+	defer listener.Close()
+	writer, err := Dial("udp", listener.LocalAddr().String())
+	testError(test, err)
+	defer writer.Close()
+	writer.Compress = true
+	writer.ChunkSize = 50
+	testLog(test, writer)
+	testAssertMessages(test, testReadMessages(test, listener, 2))
+}
+
+func TestWriterUdpTooManyChunks(test *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	testError(test, err)
+	defer listener.Close()
+	writer, err := Dial("udp", listener.LocalAddr().String())
+	testError(test, err)
+	defer writer.Close()
+	writer.ChunkSize = chunkHeaderSize + 1
+	_, err = writer.Write(bytes.Repeat([]byte("x"), MaxChunks+1))
+	assert.Error(test, err)
+}
+
+func TestWriterTcp(test *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	testError(test, err)
+	defer listener.Close()
+	received := make(chan []string)
+	go func() {
+		var messages []string
+		conn, err := listener.Accept()
+		if err != nil {
+			received <- messages
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for len(messages) < 2 {
+			message, err := reader.ReadString(0)
+			if err != nil {
+				break
+			}
+			messages = append(messages, strings.TrimSuffix(message, "\x00"))
+		}
+		received <- messages
+	}()
+	writer, err := Dial("tcp", listener.Addr().String())
+	testError(test, err)
+	defer writer.Close()
+	testLog(test, writer)
+	testAssertMessages(test, <-received)
+}
@@ -0,0 +1,189 @@
+/*
+The gelfsink package sends messages to Graylog over UDP or TCP.
+
+Messages are formatted by messageformat.MessageFormatGelf (GELF 1.1).
+Over UDP, a message larger than ChunkSize is split into GELF chunks,
+and a message can be compressed with gzip by setting Compress.
+Over TCP, each message is followed by a null byte; GELF does not allow compression over TCP.
+
+	writer, err := gelfsink.Dial("udp", "graylog:12201")
+	if err != nil {
+		...
+	}
+	defer writer.Close()
+	writer.Compress = true
+	messageLogger, _ := messagelogger.New(messagelogger.NewWriterSink(writer, &messageformat.MessageFormatGelf{}))
+
+For examples of use, see https://github.com/Senzing/go-logging/blob/main/gelfsink/gelfsink_test.go
+*/
+package gelfsink
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"fmt"
+	"net"
+
+	"github.com/senzing/go-logging/reconnect"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The Writer type sends each Write() to Graylog as one GELF message.
+A trailing newline, as added by Go's log package, is removed.
+If sending fails, the connection is opened again and the message is sent once more.
+*/
+type Writer struct {
+	Network   string // "udp" or "tcp".
+	Address   string // host:port.
+	Compress  bool   // If true, UDP messages are compressed with gzip.
+	ChunkSize int    // Largest UDP datagram, including the chunk header. If zero, DefaultChunkSize is used.
+	conn      reconnect.Conn
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The UDP datagram size used when ChunkSize is zero. It fits an Ethernet frame.
+const DefaultChunkSize = 1420
+
+// The most chunks a GELF message may be split into.
+const MaxChunks = 128
+
+// The magic bytes, message id, sequence number, and sequence count in front of each chunk.
+const chunkHeaderSize = 12
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+// The Dial function connects to a GELF input. The network is "udp" or "tcp".
+func Dial(network string, address string) (*Writer, error) {
+	switch network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("unsupported GELF network %q", network)
+	}
+	result := &Writer{
+		Network: network,
+		Address: address,
+	}
+	if err := result.conn.Connect(result.dial); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Open the connection.
+func (writer *Writer) dial() (net.Conn, error) {
+	return net.Dial(writer.Network, writer.Address)
+}
+
+// Return the datagrams for a message: the message itself, or its chunks.
+func (writer *Writer) datagrams(message []byte) ([][]byte, error) {
+	if writer.Compress {
+		var buffer bytes.Buffer
+		gzipWriter := gzip.NewWriter(&buffer)
+		if _, err := gzipWriter.Write(message); err != nil {
+			return nil, err
+		}
+		if err := gzipWriter.Close(); err != nil {
+			return nil, err
+		}
+		message = buffer.Bytes()
+	}
+	chunkSize := writer.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if len(message) <= chunkSize {
+		return [][]byte{message}, nil
+	}
+	if chunkSize <= chunkHeaderSize {
+		return nil, fmt.Errorf("GELF chunk size %d is not larger than the %d byte chunk header", chunkSize, chunkHeaderSize)
+	}
+	dataSize := chunkSize - chunkHeaderSize
+	count := (len(message) + dataSize - 1) / dataSize
+	if count > MaxChunks {
+		return nil, fmt.Errorf("GELF message of %d bytes needs %d chunks; at most %d are allowed", len(message), count, MaxChunks)
+	}
+	messageId := make([]byte, 8)
+	if _, err := rand.Read(messageId); err != nil {
+		return nil, err
+	}
+	result := make([][]byte, 0, count)
+	for sequence := 0; sequence < count; sequence++ {
+		end := (sequence + 1) * dataSize
+		if end > len(message) {
+			end = len(message)
+		}
+		chunk := make([]byte, 0, chunkHeaderSize+end-sequence*dataSize)
+		chunk = append(chunk, 0x1e, 0x0f)
+		chunk = append(chunk, messageId...)
+		chunk = append(chunk, byte(sequence), byte(count))
+		chunk = append(chunk, message[sequence*dataSize:end]...)
+		result = append(result, chunk)
+	}
+	return result, nil
+}
+
+// Return true if messages need framing.
+func (writer *Writer) isStream() bool {
+	switch writer.Network {
+	case "tcp", "tcp4", "tcp6":
+		return true
+	}
+	return false
+}
+
+// Send one message over the connection.
+func (writer *Writer) send(conn net.Conn, message []byte) error {
+	if writer.isStream() {
+		_, err := conn.Write(append(message, 0))
+		return err
+	}
+	datagrams, err := writer.datagrams(message)
+	if err != nil {
+		return err
+	}
+	for _, datagram := range datagrams {
+		if _, err := conn.Write(datagram); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Write method sends data as one GELF message.
+func (writer *Writer) Write(data []byte) (int, error) {
+	message := bytes.TrimSuffix(data, []byte("\n"))
+	if len(message) == 0 {
+		return len(data), nil
+	}
+	message = append([]byte{}, message...)
+	err := writer.conn.Send(writer.dial, func(conn net.Conn) error {
+		return writer.send(conn, message)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// The Close method closes the connection.
+func (writer *Writer) Close() error {
+	return writer.conn.Close()
+}
@@ -1,6 +1,6 @@
 module github.com/senzing/go-logging
 
-go 1.19
+go 1.21
 
 require github.com/stretchr/testify v1.8.1
 
@@ -9,5 +9,5 @@ require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	// github.com/docktermj/go-xyzzy-helpers v0.2.2
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1
 )
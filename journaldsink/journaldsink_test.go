@@ -0,0 +1,63 @@
+package journaldsink
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing/go-logging/logger"
+	"github.com/senzing/go-logging/messageformat"
+	"github.com/senzing/go-logging/messagelocation"
+	"github.com/senzing/go-logging/messagelogger"
+	"github.com/stretchr/testify/assert"
+)
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+func testError(test *testing.T, err error) {
+	if err != nil {
+		assert.Fail(test, err.Error())
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestDialMissingSocket(test *testing.T) {
+	_, err := Dial(filepath.Join(test.TempDir(), "missing"))
+	assert.Error(test, err)
+}
+
+func TestWriter(test *testing.T) {
+	socketPath := filepath.Join(test.TempDir(), "socket")
+	listener, err := net.ListenPacket("unixgram", socketPath)
+	testError(test, err)
+	defer listener.Close()
+	writer, err := Dial(socketPath)
+	testError(test, err)
+	defer writer.Close()
+
+	messageLogger, err := messagelogger.New(
+		messagelogger.NewWriterSink(writer, &messageformat.MessageFormatJournald{SyslogIdentifier: "app"}),
+		&messagelocation.MessageLocationStatic{Location: "In AFunction() at somewhere.go:1234"},
+	)
+	testError(test, err)
+	testError(test, messageLogger.Log(3001, "Bob", logger.LevelWarn))
+
+	buffer := make([]byte, 65536)
+	length, _, err := listener.ReadFrom(buffer)
+	testError(test, err)
+	expected := "MESSAGE=3001\n" +
+		"PRIORITY=4\n" +
+		"MESSAGE_ID=3001\n" +
+		"SYSLOG_IDENTIFIER=app\n" +
+		"CODE_FILE=somewhere.go\n" +
+		"CODE_LINE=1234\n" +
+		"CODE_FUNC=AFunction\n" +
+		"DETAIL_1=Bob\n" +
+		"DETAIL_2=3\n"
+	assert.Equal(test, expected, string(buffer[:length]))
+}
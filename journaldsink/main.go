@@ -0,0 +1,98 @@
+/*
+The journaldsink package sends messages to systemd-journald using its native protocol
+(https://systemd.io/JOURNAL_NATIVE_PROTOCOL/).
+
+Messages are formatted by messageformat.MessageFormatJournald,
+so each value is a journal field that can be used in filters, e.g. "journalctl MESSAGE_ID=senzing-99990001".
+
+	writer, err := journaldsink.Dial("")
+	if err != nil {
+		...
+	}
+	defer writer.Close()
+	messageLogger, _ := messagelogger.New(messagelogger.NewWriterSink(writer, &messageformat.MessageFormatJournald{}))
+
+Each message is sent as one datagram,
+so messages larger than the socket's datagram limit are not delivered.
+
+For examples of use, see https://github.com/Senzing/go-logging/blob/main/journaldsink/journaldsink_test.go
+*/
+package journaldsink
+
+import (
+	"bytes"
+	"net"
+
+	"github.com/senzing/go-logging/reconnect"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The Writer type sends each Write() to journald as one journal entry.
+If sending fails, the socket is opened again and the entry is sent once more.
+*/
+type Writer struct {
+	SocketPath string // Path of the journald socket.
+	conn       reconnect.Conn
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The path of the journald native protocol socket.
+const DefaultSocketPath = "/run/systemd/journal/socket"
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+// The Dial function connects to the journald socket. If socketPath is empty, DefaultSocketPath is used.
+func Dial(socketPath string) (*Writer, error) {
+	if len(socketPath) == 0 {
+		socketPath = DefaultSocketPath
+	}
+	result := &Writer{
+		SocketPath: socketPath,
+	}
+	if err := result.conn.Connect(result.dial); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Open the socket.
+func (writer *Writer) dial() (net.Conn, error) {
+	return net.Dial("unixgram", writer.SocketPath)
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Write method sends data, a journal entry in the native protocol, as one datagram.
+func (writer *Writer) Write(data []byte) (int, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return len(data), nil
+	}
+	err := writer.conn.Send(writer.dial, func(conn net.Conn) error {
+		_, err := conn.Write(data)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// The Close method closes the socket.
+func (writer *Writer) Close() error {
+	return writer.conn.Close()
+}
@@ -0,0 +1,175 @@
+package logfile
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senzing/go-logging/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+func testError(test *testing.T, err error) {
+	if err != nil {
+		assert.Fail(test, err.Error())
+	}
+}
+
+// A clock that advances one second each time it is read.
+func testClock(start time.Time) func() time.Time {
+	now := start
+	return func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+}
+
+func testReadFile(test *testing.T, filename string) string {
+	var reader io.Reader
+	file, err := os.Open(filename)
+	testError(test, err)
+	defer file.Close()
+	reader = file
+	if strings.HasSuffix(filename, CompressSuffix) {
+		gzipReader, err := gzip.NewReader(file)
+		testError(test, err)
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+	data, err := io.ReadAll(reader)
+	testError(test, err)
+	return string(data)
+}
+
+func testWrite(test *testing.T, logFile *LogFile, lines ...string) {
+	for _, line := range lines {
+		_, err := logFile.Write([]byte(line))
+		testError(test, err)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogFileWrite(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "logs", "test.log")
+	testObject := &LogFile{Filename: filename}
+	testWrite(test, testObject, "one\n", "two\n")
+	testError(test, testObject.Close())
+	assert.Equal(test, "one\ntwo\n", testReadFile(test, filename))
+}
+
+func TestLogFileMaxSize(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "test.log")
+	testObject := &LogFile{
+		Filename:   filename,
+		MaxSize:    8,
+		MaxBackups: 2,
+		now:        testClock(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	testWrite(test, testObject, "one\n", "two\n", "three\n", "four\n", "five\n")
+	testError(test, testObject.Close())
+
+	assert.Equal(test, "five\n", testReadFile(test, filename))
+	rotatedFiles, err := testObject.RotatedFiles()
+	testError(test, err)
+	if assert.Len(test, rotatedFiles, 2) {
+		assert.Equal(test, "four\n", testReadFile(test, rotatedFiles[0].Filename))
+		assert.Equal(test, "three\n", testReadFile(test, rotatedFiles[1].Filename))
+	}
+}
+
+func TestLogFileInterval(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "test.log")
+	now := time.Date(2000, 1, 1, 0, 59, 0, 0, time.UTC)
+	testObject := &LogFile{
+		Filename: filename,
+		Interval: time.Hour,
+		now:      func() time.Time { return now },
+	}
+	testWrite(test, testObject, "one\n", "two\n")
+	now = now.Add(2 * time.Minute)
+	testWrite(test, testObject, "three\n")
+	testError(test, testObject.Close())
+
+	assert.Equal(test, "three\n", testReadFile(test, filename))
+	rotatedFiles, err := testObject.RotatedFiles()
+	testError(test, err)
+	if assert.Len(test, rotatedFiles, 1) {
+		assert.Equal(test, "one\ntwo\n", testReadFile(test, rotatedFiles[0].Filename))
+		assert.Equal(test, now, rotatedFiles[0].RotatedAt)
+	}
+}
+
+func TestLogFileCompressAndMaxAge(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "test.log")
+	now := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	testObject := &LogFile{
+		Filename: filename,
+		MaxAge:   24 * time.Hour,
+		Compress: true,
+		now:      func() time.Time { return now },
+	}
+	testWrite(test, testObject, "one\n")
+	testError(test, testObject.Rotate())
+	now = now.Add(12 * time.Hour)
+	testWrite(test, testObject, "two\n")
+	testError(test, testObject.Rotate())
+	now = now.Add(18 * time.Hour)
+	testWrite(test, testObject, "three\n")
+	testError(test, testObject.Rotate())
+	testError(test, testObject.Close())
+
+	rotatedFiles, err := testObject.RotatedFiles()
+	testError(test, err)
+	if assert.Len(test, rotatedFiles, 2) {
+		assert.True(test, strings.HasSuffix(rotatedFiles[0].Filename, ".log"+CompressSuffix))
+		assert.Equal(test, "three\n", testReadFile(test, rotatedFiles[0].Filename))
+		assert.Equal(test, "two\n", testReadFile(test, rotatedFiles[1].Filename))
+	}
+}
+
+func TestLogFileReopen(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "test.log")
+	testObject := &LogFile{Filename: filename}
+	testWrite(test, testObject, "one\n")
+	testError(test, os.Rename(filename, filename+".1"))
+	testWrite(test, testObject, "two\n")
+	testError(test, testObject.Reopen())
+	testWrite(test, testObject, "three\n")
+	testError(test, testObject.Close())
+	assert.Equal(test, "one\ntwo\n", testReadFile(test, filename+".1"))
+	assert.Equal(test, "three\n", testReadFile(test, filename))
+}
+
+func TestLogFileClosed(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "test.log")
+	testObject := &LogFile{Filename: filename}
+	testWrite(test, testObject, "one\n")
+	testError(test, testObject.Close())
+	testError(test, os.Remove(filename))
+	_, err := testObject.Write([]byte("two\n"))
+	assert.ErrorIs(test, err, os.ErrClosed)
+	assert.ErrorIs(test, testObject.Reopen(), os.ErrClosed)
+	assert.ErrorIs(test, testObject.Rotate(), os.ErrClosed)
+	assert.NoFileExists(test, filename)
+	testError(test, testObject.Close())
+}
+
+func TestLogFileLogger(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "test.log")
+	testObject := &LogFile{Filename: filename}
+	aLogger := logger.NewWithOutput(testObject, "", 0)
+	aLogger.Info("A message")
+	testError(test, testObject.Close())
+	assert.Equal(test, "A message\n", testReadFile(test, filename))
+}
@@ -0,0 +1,362 @@
+/*
+The logfile package is an io.Writer that writes to a file and rotates it by size or by time.
+
+Rotated files are renamed with a timestamp, optionally compressed with gzip,
+and removed once there are too many or they are too old.
+A LogFile can be the output of a logger or a message logger:
+
+	logFile := &logfile.LogFile{
+		Filename:   "/var/log/senzing/loader.log",
+		MaxSize:    100 * 1024 * 1024,
+		MaxBackups: 7,
+		Compress:   true,
+	}
+	defer logFile.Close()
+	logFile.ReopenOnSignal()
+	messageLogger, _ := messagelogger.New(logFile)
+
+For examples of use, see https://github.com/Senzing/go-logging/blob/main/logfile/logfile_test.go
+*/
+package logfile
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The LogFile type writes to Filename, rotating it when it grows past MaxSize
+or when the wall clock enters a new Interval.
+The zero values of the limits disable them.
+*/
+type LogFile struct {
+	Filename   string        // Path of the current log file.
+	MaxSize    int64         // Rotate before a write would make the file larger than this many bytes.
+	Interval   time.Duration // Rotate at each multiple of Interval since the zero time, e.g. each UTC hour or day.
+	MaxBackups int           // Number of rotated files to keep.
+	MaxAge     time.Duration // How long to keep rotated files, e.g. 7 * 24 * time.Hour for 7 days.
+	Compress   bool          // If true, rotated files are compressed with gzip.
+	FileMode   os.FileMode   // Permissions of new files. If 0, DefaultFileMode is used.
+
+	mutex       sync.Mutex
+	file        *os.File
+	isClosed    bool // Set by Close(); later writes return os.ErrClosed.
+	size        int64
+	openedAt    time.Time
+	signals     chan os.Signal
+	maintenance sync.WaitGroup
+	maintaining sync.Mutex       // Compression and cleanup run one at a time.
+	now         func() time.Time // If nil, time.Now is used.
+}
+
+// The RotatedFile type describes a file produced by rotation.
+type RotatedFile struct {
+	Filename  string    // Path of the rotated file.
+	RotatedAt time.Time // When the file was rotated, from its name.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The permissions of new log files when FileMode is 0.
+const DefaultFileMode os.FileMode = 0640
+
+// The layout of the timestamp added to the names of rotated files.
+const TimestampLayout = "20060102T150405.000000000"
+
+// The suffix added to the names of compressed files.
+const CompressSuffix = ".gz"
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+func (logFile *LogFile) currentTime() time.Time {
+	if logFile.now == nil {
+		return time.Now()
+	}
+	return logFile.now()
+}
+
+// Open Filename for appending.  The caller holds the mutex.
+func (logFile *LogFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(logFile.Filename), 0755); err != nil {
+		return err
+	}
+	fileMode := logFile.FileMode
+	if fileMode == 0 {
+		fileMode = DefaultFileMode
+	}
+	file, err := os.OpenFile(logFile.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, fileMode)
+	if err != nil {
+		return err
+	}
+	fileInfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	logFile.file = file
+	logFile.size = fileInfo.Size()
+	logFile.openedAt = logFile.currentTime()
+	if logFile.size > 0 {
+		logFile.openedAt = fileInfo.ModTime()
+	}
+	return nil
+}
+
+// Close the current file, if open.  The caller holds the mutex.
+func (logFile *LogFile) closeFile() error {
+	if logFile.file == nil {
+		return nil
+	}
+	err := logFile.file.Close()
+	logFile.file = nil
+	return err
+}
+
+// Return true if writing length bytes requires a rotation.  The caller holds the mutex.
+func (logFile *LogFile) isRotationDue(length int) bool {
+	if logFile.MaxSize > 0 && logFile.size > 0 && logFile.size+int64(length) > logFile.MaxSize {
+		return true
+	}
+	if logFile.Interval > 0 && logFile.size > 0 {
+		return !logFile.currentTime().Truncate(logFile.Interval).Equal(logFile.openedAt.Truncate(logFile.Interval))
+	}
+	return false
+}
+
+// Rename the current file and open a new one.  The caller holds the mutex.
+func (logFile *LogFile) rotate() error {
+	if err := logFile.closeFile(); err != nil {
+		return err
+	}
+	now := logFile.currentTime()
+	rotatedFilename := logFile.rotatedFilename(now)
+	err := os.Rename(logFile.Filename, rotatedFilename)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		logFile.maintenance.Add(1)
+		go func() {
+			defer logFile.maintenance.Done()
+			logFile.maintain(rotatedFilename, now.Add(-logFile.MaxAge))
+		}()
+	}
+	return logFile.open()
+}
+
+// Return the name of the file rotated at a given time, e.g. "loader-20230102T150405.000000000.log".
+func (logFile *LogFile) rotatedFilename(rotatedAt time.Time) string {
+	extension := filepath.Ext(logFile.Filename)
+	base := strings.TrimSuffix(logFile.Filename, extension)
+	return base + "-" + rotatedAt.UTC().Format(TimestampLayout) + extension
+}
+
+// Compress a rotated file, if requested, and remove the rotated files that are no longer kept.
+func (logFile *LogFile) maintain(rotatedFilename string, cutoff time.Time) {
+	logFile.maintaining.Lock()
+	defer logFile.maintaining.Unlock()
+	if logFile.Compress {
+		if compressFile(rotatedFilename) == nil {
+			os.Remove(rotatedFilename)
+		}
+	}
+	logFile.removeOldFiles(cutoff)
+}
+
+// Remove the rotated files beyond MaxBackups or rotated before cutoff, if MaxAge is set.
+func (logFile *LogFile) removeOldFiles(cutoff time.Time) {
+	if logFile.MaxBackups <= 0 && logFile.MaxAge <= 0 {
+		return
+	}
+	rotatedFiles, err := logFile.RotatedFiles()
+	if err != nil {
+		return
+	}
+	for index, rotatedFile := range rotatedFiles {
+		isTooMany := logFile.MaxBackups > 0 && index >= logFile.MaxBackups
+		isTooOld := logFile.MaxAge > 0 && rotatedFile.RotatedAt.Before(cutoff)
+		if isTooMany || isTooOld {
+			os.Remove(rotatedFile.Filename)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Write filename to filename + CompressSuffix using gzip.
+func compressFile(filename string) error {
+	source, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	fileInfo, err := source.Stat()
+	if err != nil {
+		return err
+	}
+	target, err := os.OpenFile(filename+CompressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fileInfo.Mode())
+	if err != nil {
+		return err
+	}
+	gzipWriter := gzip.NewWriter(target)
+	_, err = io.Copy(gzipWriter, source)
+	err = errors.Join(err, gzipWriter.Close(), target.Close())
+	if err != nil {
+		os.Remove(filename + CompressSuffix)
+	}
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Write method writes data to the log file, opening or rotating it first if needed.
+After Close(), it returns os.ErrClosed.
+*/
+func (logFile *LogFile) Write(data []byte) (int, error) {
+	logFile.mutex.Lock()
+	defer logFile.mutex.Unlock()
+	if logFile.isClosed {
+		return 0, os.ErrClosed
+	}
+	if logFile.file == nil {
+		if err := logFile.open(); err != nil {
+			return 0, err
+		}
+	}
+	if logFile.isRotationDue(len(data)) {
+		if err := logFile.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	written, err := logFile.file.Write(data)
+	logFile.size += int64(written)
+	return written, err
+}
+
+/*
+The Close method closes the log file, stops signal handling, and waits for compression and cleanup to finish.
+The LogFile cannot be written to again.
+*/
+func (logFile *LogFile) Close() error {
+	logFile.mutex.Lock()
+	logFile.isClosed = true
+	if logFile.signals != nil {
+		signal.Stop(logFile.signals)
+		close(logFile.signals)
+		logFile.signals = nil
+	}
+	err := logFile.closeFile()
+	logFile.mutex.Unlock()
+	logFile.maintenance.Wait()
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+// The RotatedFiles method lists the rotated files, newest first.
+func (logFile *LogFile) RotatedFiles() ([]RotatedFile, error) {
+	extension := filepath.Ext(logFile.Filename)
+	prefix := filepath.Base(strings.TrimSuffix(logFile.Filename, extension)) + "-"
+	directory := filepath.Dir(logFile.Filename)
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+	var result []RotatedFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		timestamp := strings.TrimPrefix(strings.TrimSuffix(name, CompressSuffix), prefix)
+		if !strings.HasSuffix(timestamp, extension) {
+			continue
+		}
+		rotatedAt, err := time.Parse(TimestampLayout, strings.TrimSuffix(timestamp, extension))
+		if err != nil {
+			continue
+		}
+		result = append(result, RotatedFile{
+			Filename:  filepath.Join(directory, name),
+			RotatedAt: rotatedAt,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RotatedAt.After(result[j].RotatedAt)
+	})
+	return result, nil
+}
+
+/*
+The Reopen method closes and reopens Filename, e.g. after an external tool has moved it.
+After Close(), it returns os.ErrClosed.
+*/
+func (logFile *LogFile) Reopen() error {
+	logFile.mutex.Lock()
+	defer logFile.mutex.Unlock()
+	if logFile.isClosed {
+		return os.ErrClosed
+	}
+	if err := logFile.closeFile(); err != nil {
+		return err
+	}
+	return logFile.open()
+}
+
+/*
+The ReopenOnSignal method calls Reopen() each time one of the signals is received.
+If no signals are given, SIGHUP is used.
+Signal handling stops when the LogFile is closed.
+*/
+func (logFile *LogFile) ReopenOnSignal(signals ...os.Signal) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+	logFile.mutex.Lock()
+	defer logFile.mutex.Unlock()
+	if logFile.signals != nil {
+		signal.Stop(logFile.signals)
+		close(logFile.signals)
+	}
+	logFile.signals = make(chan os.Signal, 1)
+	signal.Notify(logFile.signals, signals...)
+	go func(received chan os.Signal) {
+		for range received {
+			logFile.Reopen()
+		}
+	}(logFile.signals)
+}
+
+// The Rotate method rotates the log file now. After Close(), it returns os.ErrClosed.
+func (logFile *LogFile) Rotate() error {
+	logFile.mutex.Lock()
+	defer logFile.mutex.Unlock()
+	if logFile.isClosed {
+		return os.ErrClosed
+	}
+	return logFile.rotate()
+}
@@ -12,6 +12,7 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"strings"
 )
@@ -25,30 +26,42 @@ The LoggerDefault type is for logging messages based on the following levels:
 TRACE, DEBUG, INFO, WARN, ERROR, FATAL, and PANIC.
 */
 type LoggerDefault struct {
-	level   Level
-	isDebug bool
-	isError bool
-	isFatal bool
-	isInfo  bool
-	isPanic bool
-	isTrace bool
-	isWarn  bool
+	level  atomicLevel // Safe to read and change while logging.
+	output *log.Logger // If nil, Go's standard logger is used.
 }
 
 // ----------------------------------------------------------------------------
 // Internal methods
 // ----------------------------------------------------------------------------
 
+// Return the instance's log.Logger, falling back to Go's standard logger.
+func (logger *LoggerDefault) getOutput() *log.Logger {
+	if logger.output != nil {
+		return logger.output
+	}
+	return log.Default()
+}
+
+// Return the destination of the instance's log.Logger.
+func (logger *LoggerDefault) getWriter() io.Writer {
+	return logger.getOutput().Writer()
+}
+
+// Give the instance its own log.Logger so that it no longer shares Go's standard logger.
+func (logger *LoggerDefault) setOutput(writer io.Writer, prefix string, flags int) {
+	logger.output = log.New(writer, prefix, flags)
+}
+
+func (logger *LoggerDefault) print(debugLevelName string, v ...interface{}) LoggerInterface {
+	calldepth := 3
+	logger.getOutput().Output(calldepth, fmt.Sprint(v...))
+	return logger
+}
+
 func (logger *LoggerDefault) printf(debugLevelName string, format string, v ...interface{}) LoggerInterface {
-	var message string
 	calldepth := 3
-	if format == noFormat {
-		message = fmt.Sprint(v...)
-	} else {
-		message = fmt.Sprintf(format, v...)
-	}
-	log.Output(calldepth, message)
-	return loggerInstance
+	logger.getOutput().Output(calldepth, fmt.Sprintf(format, v...))
+	return logger
 }
 
 // ----------------------------------------------------------------------------
@@ -57,15 +70,15 @@ func (logger *LoggerDefault) printf(debugLevelName string, format string, v ...i
 
 // Debug() logs a DEBUG message.
 func (logger *LoggerDefault) Debug(v ...interface{}) LoggerInterface {
-	if logger.isDebug {
-		logger.printf(LevelDebugName, noFormat, v...)
+	if logger.level.isEnabled(LevelDebug) {
+		logger.print(LevelDebugName, v...)
 	}
 	return logger
 }
 
 // Debugf() logs a formatted DEBUG message.
 func (logger *LoggerDefault) Debugf(format string, v ...interface{}) LoggerInterface {
-	if logger.isDebug {
+	if logger.level.isEnabled(LevelDebug) {
 		logger.printf(LevelDebugName, format, v...)
 	}
 	return logger
@@ -73,15 +86,15 @@ func (logger *LoggerDefault) Debugf(format string, v ...interface{}) LoggerInter
 
 // Error() logs a ERROR message.
 func (logger *LoggerDefault) Error(v ...interface{}) LoggerInterface {
-	if logger.isError {
-		logger.printf(LevelErrorName, noFormat, v...)
+	if logger.level.isEnabled(LevelError) {
+		logger.print(LevelErrorName, v...)
 	}
 	return logger
 }
 
 // Errorf() logs a formatted ERROR message.
 func (logger *LoggerDefault) Errorf(format string, v ...interface{}) LoggerInterface {
-	if logger.isError {
+	if logger.level.isEnabled(LevelError) {
 		logger.printf(LevelErrorName, format, v...)
 	}
 	return logger
@@ -89,43 +102,43 @@ func (logger *LoggerDefault) Errorf(format string, v ...interface{}) LoggerInter
 
 // Fatal() logs a FATAL message.
 func (logger *LoggerDefault) Fatal(v ...interface{}) LoggerInterface {
-	if logger.isFatal {
-		logger.printf(LevelFatalName, noFormat, v...)
-		log.Fatal("")
+	if logger.level.isEnabled(LevelFatal) {
+		logger.print(LevelFatalName, v...)
+		logger.getOutput().Fatal("")
 	}
 	return logger
 }
 
 // Fatalf() logs a formatted FATAL message.
 func (logger *LoggerDefault) Fatalf(format string, v ...interface{}) LoggerInterface {
-	if logger.isFatal {
+	if logger.level.isEnabled(LevelFatal) {
 		logger.printf(LevelFatalName, format, v...)
-		log.Fatal("")
+		logger.getOutput().Fatal("")
 	}
 	return logger
 }
 
 // GetLogLevel() gets the logger instance logging level.
 func (logger *LoggerDefault) GetLogLevel() Level {
-	return logger.level
+	return logger.level.get()
 }
 
 // GetLogLevelAsString() gets the logger instance logging level in string representation.
 func (logger *LoggerDefault) GetLogLevelAsString() string {
-	return LevelToTextMap[logger.level]
+	return LevelToTextMap[logger.level.get()]
 }
 
 // Info() logs a INFO message.
 func (logger *LoggerDefault) Info(v ...interface{}) LoggerInterface {
-	if logger.isInfo {
-		logger.printf(LevelInfoName, noFormat, v...)
+	if logger.level.isEnabled(LevelInfo) {
+		logger.print(LevelInfoName, v...)
 	}
 	return logger
 }
 
 // Infof() logs a formatted INFO message.
 func (logger *LoggerDefault) Infof(format string, v ...interface{}) LoggerInterface {
-	if logger.isInfo {
+	if logger.level.isEnabled(LevelInfo) {
 		logger.printf(LevelInfoName, format, v...)
 	}
 	return logger
@@ -133,67 +146,70 @@ func (logger *LoggerDefault) Infof(format string, v ...interface{}) LoggerInterf
 
 // IsDebug() returns true if the logger instance will log a DEBUG message.
 func (logger *LoggerDefault) IsDebug() bool {
-	return logger.isDebug
+	return logger.level.isEnabled(LevelDebug)
 }
 
 // IsError() returns true if the logger instance will log a ERROR message.
 func (logger *LoggerDefault) IsError() bool {
-	return logger.isError
+	return logger.level.isEnabled(LevelError)
 }
 
 // IsFatal() returns true if the logger instance will log a FATAL message.
 func (logger *LoggerDefault) IsFatal() bool {
-	return logger.isFatal
+	return logger.level.isEnabled(LevelFatal)
 }
 
 // IsInfo() returns true if the logger instance will log a INFO message.
 func (logger *LoggerDefault) IsInfo() bool {
-	return logger.isInfo
+	return logger.level.isEnabled(LevelInfo)
 }
 
 // IsPanic() returns true if the logger instance will log a PANIC message.
 func (logger *LoggerDefault) IsPanic() bool {
-	return logger.isPanic
+	return logger.level.isEnabled(LevelPanic)
 }
 
 // IsTrace() returns true if the logger instance will log a TRACE message.
 func (logger *LoggerDefault) IsTrace() bool {
-	return logger.isTrace
+	return logger.level.isEnabled(LevelTrace)
 }
 
 // IsWarn() returns true if the logger instance will log a WARN message.
 func (logger *LoggerDefault) IsWarn() bool {
-	return logger.isWarn
+	return logger.level.isEnabled(LevelWarn)
+}
+
+// Log() logs a message at a level without exiting or panicking.
+// A FATAL or PANIC message is written like any other,
+// so several loggers can write it before the program exits or panics.
+func (logger *LoggerDefault) Log(level Level, v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(level) {
+		logger.print(LevelToTextMap[level], v...)
+	}
+	return logger
 }
 
 // Panic() logs a PANIC message.
 func (logger *LoggerDefault) Panic(v ...interface{}) LoggerInterface {
-	if logger.isPanic {
-		logger.printf(LevelPanicName, noFormat, v...)
-		log.Panic("")
+	if logger.level.isEnabled(LevelPanic) {
+		logger.print(LevelPanicName, v...)
+		logger.getOutput().Panic("")
 	}
 	return logger
 }
 
 // Panicf() logs a formatted PANIC message.
 func (logger *LoggerDefault) Panicf(format string, v ...interface{}) LoggerInterface {
-	if logger.isPanic {
+	if logger.level.isEnabled(LevelPanic) {
 		logger.printf(LevelPanicName, format, v...)
-		log.Panic("")
+		logger.getOutput().Panic("")
 	}
 	return logger
 }
 
 // SetLogLevel() sets the logger instance logging level.
 func (logger *LoggerDefault) SetLogLevel(level Level) LoggerInterface {
-	logger.level = level
-	logger.isPanic = level <= LevelPanic
-	logger.isFatal = level <= LevelFatal
-	logger.isError = level <= LevelError
-	logger.isWarn = level <= LevelWarn
-	logger.isInfo = level <= LevelInfo
-	logger.isDebug = level <= LevelDebug
-	logger.isTrace = level <= LevelTrace
+	logger.level.set(level)
 	return logger
 }
 
@@ -210,15 +226,15 @@ func (logger *LoggerDefault) SetLogLevelFromString(levelString string) LoggerInt
 
 // Trace() logs a TRACE message.
 func (logger *LoggerDefault) Trace(v ...interface{}) LoggerInterface {
-	if logger.isTrace {
-		logger.printf(LevelTraceName, noFormat, v...)
+	if logger.level.isEnabled(LevelTrace) {
+		logger.print(LevelTraceName, v...)
 	}
 	return logger
 }
 
 // Tracef() logs a formatted TRACE message.
 func (logger *LoggerDefault) Tracef(format string, v ...interface{}) LoggerInterface {
-	if logger.isTrace {
+	if logger.level.isEnabled(LevelTrace) {
 		logger.printf(LevelTraceName, format, v...)
 	}
 	return logger
@@ -226,16 +242,38 @@ func (logger *LoggerDefault) Tracef(format string, v ...interface{}) LoggerInter
 
 // Warn() logs a WARN message.
 func (logger *LoggerDefault) Warn(v ...interface{}) LoggerInterface {
-	if logger.isWarn {
-		logger.printf(LevelWarnName, noFormat, v...)
+	if logger.level.isEnabled(LevelWarn) {
+		logger.print(LevelWarnName, v...)
 	}
 	return logger
 }
 
 // Warnf() logs a formatted WARN message.
 func (logger *LoggerDefault) Warnf(format string, v ...interface{}) LoggerInterface {
-	if logger.isWarn {
+	if logger.level.isEnabled(LevelWarn) {
 		logger.printf(LevelWarnName, format, v...)
 	}
 	return logger
 }
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+// SetFlags() sets the output flags (e.g. log.LstdFlags) of the logger instance.
+func (logger *LoggerDefault) SetFlags(flags int) *LoggerDefault {
+	logger.setOutput(logger.getWriter(), logger.getOutput().Prefix(), flags)
+	return logger
+}
+
+// SetOutput() sets the destination of the logger instance.
+func (logger *LoggerDefault) SetOutput(writer io.Writer) *LoggerDefault {
+	logger.setOutput(writer, logger.getOutput().Prefix(), logger.getOutput().Flags())
+	return logger
+}
+
+// SetPrefix() sets the prefix written at the beginning of each line by the logger instance.
+func (logger *LoggerDefault) SetPrefix(prefix string) *LoggerDefault {
+	logger.setOutput(logger.getWriter(), prefix, logger.getOutput().Flags())
+	return logger
+}
@@ -0,0 +1,323 @@
+/*
+The LoggerSlog implementation writes log records into a log/slog Handler
+(https://pkg.go.dev/log/slog#Handler).
+
+It implements the same levels and IsXxxx() guards as LoggerDefault.
+*/
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The LoggerSlog type is for logging messages to a slog.Handler based on the following levels:
+TRACE, DEBUG, INFO, WARN, ERROR, FATAL, and PANIC.
+*/
+type LoggerSlog struct {
+	Handler slog.Handler // Destination of log records.
+	level   atomicLevel  // Safe to read and change while logging.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// SlogLevelXxxx values are slog.Level values for the levels log/slog does not define.
+const (
+	SlogLevelTrace = slog.LevelDebug - 4
+	SlogLevelFatal = slog.LevelError + 4
+	SlogLevelPanic = slog.LevelError + 8
+)
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+// Create a new instance of the logger that writes to a slog.Handler.
+func NewSlog(handler slog.Handler) *LoggerSlog {
+	result := &LoggerSlog{
+		Handler: handler,
+	}
+	result.SetLogLevel(LevelInfo)
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+// LevelToSlogLevel() maps a Level to the corresponding slog.Level.
+func LevelToSlogLevel(level Level) slog.Level {
+	switch level {
+	case LevelTrace:
+		return SlogLevelTrace
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	case LevelFatal:
+		return SlogLevelFatal
+	case LevelPanic:
+		return SlogLevelPanic
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// SlogLevelToLevel() maps a slog.Level to the closest Level at or below it.
+func SlogLevelToLevel(slogLevel slog.Level) Level {
+	switch {
+	case slogLevel >= SlogLevelPanic:
+		return LevelPanic
+	case slogLevel >= SlogLevelFatal:
+		return LevelFatal
+	case slogLevel >= slog.LevelError:
+		return LevelError
+	case slogLevel >= slog.LevelWarn:
+		return LevelWarn
+	case slogLevel >= slog.LevelInfo:
+		return LevelInfo
+	case slogLevel >= slog.LevelDebug:
+		return LevelDebug
+	default:
+		return LevelTrace
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+func (logger *LoggerSlog) handle(level Level, message string) LoggerInterface {
+	if logger.Handler == nil {
+		return logger
+	}
+	ctx := context.Background()
+	slogLevel := LevelToSlogLevel(level)
+	if !logger.Handler.Enabled(ctx, slogLevel) {
+		return logger
+	}
+
+	// Skip runtime.Callers, handle, print or printf, and the LoggerSlog method.
+
+	var pcs [1]uintptr
+	runtime.Callers(4, pcs[:])
+	record := slog.NewRecord(time.Now(), slogLevel, message, pcs[0])
+	logger.Handler.Handle(ctx, record)
+	return logger
+}
+
+func (logger *LoggerSlog) print(level Level, v ...interface{}) LoggerInterface {
+	return logger.handle(level, fmt.Sprint(v...))
+}
+
+func (logger *LoggerSlog) printf(level Level, format string, v ...interface{}) LoggerInterface {
+	return logger.handle(level, fmt.Sprintf(format, v...))
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// Debug() logs a DEBUG message.
+func (logger *LoggerSlog) Debug(v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelDebug) {
+		logger.print(LevelDebug, v...)
+	}
+	return logger
+}
+
+// Debugf() logs a formatted DEBUG message.
+func (logger *LoggerSlog) Debugf(format string, v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelDebug) {
+		logger.printf(LevelDebug, format, v...)
+	}
+	return logger
+}
+
+// Error() logs a ERROR message.
+func (logger *LoggerSlog) Error(v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelError) {
+		logger.print(LevelError, v...)
+	}
+	return logger
+}
+
+// Errorf() logs a formatted ERROR message.
+func (logger *LoggerSlog) Errorf(format string, v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelError) {
+		logger.printf(LevelError, format, v...)
+	}
+	return logger
+}
+
+// Fatal() logs a FATAL message and exits.
+func (logger *LoggerSlog) Fatal(v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelFatal) {
+		logger.print(LevelFatal, v...)
+		os.Exit(1)
+	}
+	return logger
+}
+
+// Fatalf() logs a formatted FATAL message and exits.
+func (logger *LoggerSlog) Fatalf(format string, v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelFatal) {
+		logger.printf(LevelFatal, format, v...)
+		os.Exit(1)
+	}
+	return logger
+}
+
+// GetLogLevel() gets the logger instance logging level.
+func (logger *LoggerSlog) GetLogLevel() Level {
+	return logger.level.get()
+}
+
+// GetLogLevelAsString() gets the logger instance logging level in string representation.
+func (logger *LoggerSlog) GetLogLevelAsString() string {
+	return LevelToTextMap[logger.level.get()]
+}
+
+// Info() logs a INFO message.
+func (logger *LoggerSlog) Info(v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelInfo) {
+		logger.print(LevelInfo, v...)
+	}
+	return logger
+}
+
+// Infof() logs a formatted INFO message.
+func (logger *LoggerSlog) Infof(format string, v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelInfo) {
+		logger.printf(LevelInfo, format, v...)
+	}
+	return logger
+}
+
+// IsDebug() returns true if the logger instance will log a DEBUG message.
+func (logger *LoggerSlog) IsDebug() bool {
+	return logger.level.isEnabled(LevelDebug)
+}
+
+// IsError() returns true if the logger instance will log a ERROR message.
+func (logger *LoggerSlog) IsError() bool {
+	return logger.level.isEnabled(LevelError)
+}
+
+// IsFatal() returns true if the logger instance will log a FATAL message.
+func (logger *LoggerSlog) IsFatal() bool {
+	return logger.level.isEnabled(LevelFatal)
+}
+
+// IsInfo() returns true if the logger instance will log a INFO message.
+func (logger *LoggerSlog) IsInfo() bool {
+	return logger.level.isEnabled(LevelInfo)
+}
+
+// IsPanic() returns true if the logger instance will log a PANIC message.
+func (logger *LoggerSlog) IsPanic() bool {
+	return logger.level.isEnabled(LevelPanic)
+}
+
+// IsTrace() returns true if the logger instance will log a TRACE message.
+func (logger *LoggerSlog) IsTrace() bool {
+	return logger.level.isEnabled(LevelTrace)
+}
+
+// IsWarn() returns true if the logger instance will log a WARN message.
+func (logger *LoggerSlog) IsWarn() bool {
+	return logger.level.isEnabled(LevelWarn)
+}
+
+// Log() logs a message at a level without exiting or panicking.
+// A FATAL or PANIC message is written like any other,
+// so several loggers can write it before the program exits or panics.
+func (logger *LoggerSlog) Log(level Level, v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(level) {
+		logger.print(level, v...)
+	}
+	return logger
+}
+
+// Panic() logs a PANIC message and panics.
+func (logger *LoggerSlog) Panic(v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelPanic) {
+		message := fmt.Sprint(v...)
+		logger.print(LevelPanic, message)
+		panic(message)
+	}
+	return logger
+}
+
+// Panicf() logs a formatted PANIC message and panics.
+func (logger *LoggerSlog) Panicf(format string, v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelPanic) {
+		message := fmt.Sprintf(format, v...)
+		logger.print(LevelPanic, message)
+		panic(message)
+	}
+	return logger
+}
+
+// SetLogLevel() sets the logger instance logging level.
+func (logger *LoggerSlog) SetLogLevel(level Level) LoggerInterface {
+	logger.level.set(level)
+	return logger
+}
+
+// SetLogLevelFromString() sets the logger instance logging level using a string representation.
+func (logger *LoggerSlog) SetLogLevelFromString(levelString string) LoggerInterface {
+	upperLevelString := strings.ToUpper(levelString)
+	level, ok := TextToLevelMap[upperLevelString]
+	if !ok {
+		level = LevelPanic
+	}
+	logger.SetLogLevel(level)
+	return logger
+}
+
+// Trace() logs a TRACE message.
+func (logger *LoggerSlog) Trace(v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelTrace) {
+		logger.print(LevelTrace, v...)
+	}
+	return logger
+}
+
+// Tracef() logs a formatted TRACE message.
+func (logger *LoggerSlog) Tracef(format string, v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelTrace) {
+		logger.printf(LevelTrace, format, v...)
+	}
+	return logger
+}
+
+// Warn() logs a WARN message.
+func (logger *LoggerSlog) Warn(v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelWarn) {
+		logger.print(LevelWarn, v...)
+	}
+	return logger
+}
+
+// Warnf() logs a formatted WARN message.
+func (logger *LoggerSlog) Warnf(format string, v ...interface{}) LoggerInterface {
+	if logger.level.isEnabled(LevelWarn) {
+		logger.printf(LevelWarn, format, v...)
+	}
+	return logger
+}
@@ -1,6 +1,9 @@
 package logger
 
 import (
+	"bytes"
+	"log"
+	"log/slog"
 	"testing"
 	"time"
 
@@ -225,6 +228,79 @@ func TestTrace(test *testing.T) {
 	assert.NotZero(test, Tracef("test %s", "something"), "format")
 }
 
+// -- Output ------------------------------------------------------------------
+
+func TestNewWithOutput(test *testing.T) {
+	var buffer bytes.Buffer
+	testObject := NewWithOutput(&buffer, "test: ", 0)
+	testObject.Info("info")
+	testObject.Debugf("debug %s", "something")
+	testObject.Warnf("warn %s", "something")
+	assert.Equal(test, "test: info\ntest: warn something\n", buffer.String())
+}
+
+func TestNewWithOutputIndependent(test *testing.T) {
+	var buffer1 bytes.Buffer
+	var buffer2 bytes.Buffer
+	testObject1 := NewWithOutput(&buffer1, "one: ", 0)
+	testObject2 := NewWithOutput(&buffer2, "two: ", log.Lshortfile)
+	testObject1.Info("first")
+	testObject2.Info("second")
+	assert.Equal(test, "one: first\n", buffer1.String())
+	assert.Equal(test, "two: logger_test.go:", buffer2.String()[:20])
+}
+
+func TestLog(test *testing.T) {
+	var buffer bytes.Buffer
+	testObject := NewWithOutput(&buffer, "", 0)
+	testObject.SetLogLevel(LevelWarn)
+	testObject.Log(LevelInfo, "info")
+	testObject.Log(LevelFatal, "fatal")
+	testObject.Log(LevelPanic, "panic")
+	assert.Equal(test, "fatal\npanic\n", buffer.String())
+}
+
+func TestSetOutput(test *testing.T) {
+	var buffer bytes.Buffer
+	testObject := New()
+	testObject.SetOutput(&buffer).SetPrefix("prefix: ").SetFlags(0)
+	testObject.Error("error")
+	assert.Equal(test, "prefix: error\n", buffer.String())
+}
+
+// -- Slog --------------------------------------------------------------------
+
+func TestNewSlog(test *testing.T) {
+	var buffer bytes.Buffer
+	handler := slog.NewTextHandler(&buffer, &slog.HandlerOptions{
+		Level: SlogLevelTrace,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+	testObject := NewSlog(handler)
+	testObject.Info("info")
+	testObject.Tracef("trace %s", "something")
+	testObject.SetLogLevel(LevelTrace)
+	testObject.Tracef("trace %s", "something")
+	testObject.Warn("warn")
+	testObject.Log(LevelFatal, "fatal")
+	assert.Equal(test, "level=INFO msg=info\nlevel=DEBUG-4 msg=\"trace something\"\nlevel=WARN msg=warn\nlevel=ERROR+4 msg=fatal\n", buffer.String())
+}
+
+func TestSlogLevels(test *testing.T) {
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			assert.Equal(test, testCase.logLevel, SlogLevelToLevel(LevelToSlogLevel(testCase.logLevel)))
+		})
+	}
+	assert.Equal(test, LevelInfo, SlogLevelToLevel(slog.LevelInfo+1))
+	assert.Equal(test, LevelTrace, SlogLevelToLevel(slog.LevelDebug-1))
+}
+
 // -- Miscellaneous -----------------------------------------------------------
 
 func TestFluentInterface(test *testing.T) {
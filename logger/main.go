@@ -5,16 +5,31 @@ For examples of use, see https://github.com/Senzing/go-logging/blob/main/logger/
 */
 package logger
 
-import "log"
+import (
+	"io"
+	"sync/atomic"
+)
 
 // ----------------------------------------------------------------------------
 // Types
 // ----------------------------------------------------------------------------
 
+// The Flags type is used to identify an integer as output flags (e.g. log.LstdFlags) in parameters.
+// See https://pkg.go.dev/log#pkg-constants
+type Flags int
+
 // The Level type is used to identify the integer is the detail parameters
 // and is used in LevelXxxxx constants.
 type Level int
 
+// The Prefix type is used to identify a string as the output prefix in parameters.
+type Prefix string
+
+// A log level that can be read and changed concurrently. The zero value has no level, so no level is enabled.
+type atomicLevel struct {
+	value atomic.Int32 // The level plus one, or 0 if no level is set.
+}
+
 // The LoggerInterface type defines guards, logging methods, and get/set of logging level.
 type LoggerInterface interface {
 	Debug(v ...interface{}) LoggerInterface                   // Log a DEBUG message.
@@ -48,8 +63,6 @@ type LoggerInterface interface {
 // Constants
 // ----------------------------------------------------------------------------
 
-const noFormat = ""
-
 /*
 LevelXxxx values are an enumeration of typed integers representing logging levels.
 Order is important for the LevelXxxx variables.
@@ -109,12 +122,44 @@ var TextToLevelMap = map[string]Level{
 // ----------------------------------------------------------------------------
 
 // Create a new default instance of the logger.
+// It writes to Go's standard logger.
 func New() *LoggerDefault {
 	result := &LoggerDefault{}
 	result.SetLogLevel(LevelInfo)
 	return result
 }
 
+// Create a new instance of the logger with its own destination, prefix, and flags.
+// For flags, see https://pkg.go.dev/log#pkg-constants
+func NewWithOutput(writer io.Writer, prefix string, flags int) *LoggerDefault {
+	result := New()
+	result.setOutput(writer, prefix, flags)
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Return the level, or LevelTrace if no level is set.
+func (level *atomicLevel) get() Level {
+	if value := level.value.Load(); value > 0 {
+		return Level(value - 1)
+	}
+	return LevelTrace
+}
+
+// Return true if a level is set and messages at messageLevel are logged.
+func (level *atomicLevel) isEnabled(messageLevel Level) bool {
+	value := level.value.Load()
+	return value > 0 && Level(value-1) <= messageLevel
+}
+
+// Set the level.
+func (level *atomicLevel) set(newLevel Level) {
+	level.value.Store(int32(newLevel) + 1)
+}
+
 // ----------------------------------------------------------------------------
 // Internal functions
 // ----------------------------------------------------------------------------
@@ -131,7 +176,7 @@ func init() {
 // Debug() logs a DEBUG message.
 func Debug(v ...interface{}) LoggerInterface {
 	if loggerInstance.IsDebug() {
-		loggerInstance.printf(LevelDebugName, noFormat, v...)
+		loggerInstance.print(LevelDebugName, v...)
 	}
 	return loggerInstance
 }
@@ -147,7 +192,7 @@ func Debugf(format string, v ...interface{}) LoggerInterface {
 // Error() logs a ERROR message.
 func Error(v ...interface{}) LoggerInterface {
 	if loggerInstance.IsError() {
-		loggerInstance.printf(LevelErrorName, noFormat, v...)
+		loggerInstance.print(LevelErrorName, v...)
 	}
 	return loggerInstance
 }
@@ -163,8 +208,8 @@ func Errorf(format string, v ...interface{}) LoggerInterface {
 // Fatal() logs a FATAL message.
 func Fatal(v ...interface{}) LoggerInterface {
 	if loggerInstance.IsFatal() {
-		loggerInstance.printf(LevelFatalName, noFormat, v...)
-		log.Fatal("")
+		loggerInstance.print(LevelFatalName, v...)
+		loggerInstance.getOutput().Fatal("")
 	}
 	return loggerInstance
 }
@@ -173,7 +218,7 @@ func Fatal(v ...interface{}) LoggerInterface {
 func Fatalf(format string, v ...interface{}) LoggerInterface {
 	if loggerInstance.IsFatal() {
 		loggerInstance.printf(LevelFatalName, format, v...)
-		log.Fatal("")
+		loggerInstance.getOutput().Fatal("")
 	}
 	return loggerInstance
 }
@@ -191,7 +236,7 @@ func GetLogLevelAsString() string {
 // Info() logs a INFO message.
 func Info(v ...interface{}) LoggerInterface {
 	if loggerInstance.IsInfo() {
-		loggerInstance.printf(LevelInfoName, noFormat, v...)
+		loggerInstance.print(LevelInfoName, v...)
 	}
 	return loggerInstance
 }
@@ -239,11 +284,19 @@ func IsWarn() bool {
 	return loggerInstance.IsWarn()
 }
 
+// Log() logs a message at a level without exiting or panicking.
+func Log(level Level, v ...interface{}) LoggerInterface {
+	if level >= loggerInstance.GetLogLevel() {
+		loggerInstance.print(LevelToTextMap[level], v...)
+	}
+	return loggerInstance
+}
+
 // Panic() logs a PANIC message.
 func Panic(v ...interface{}) LoggerInterface {
 	if loggerInstance.IsPanic() {
-		loggerInstance.printf(LevelPanicName, noFormat, v...)
-		log.Panic("")
+		loggerInstance.print(LevelPanicName, v...)
+		loggerInstance.getOutput().Panic("")
 	}
 	return loggerInstance
 }
@@ -252,7 +305,7 @@ func Panic(v ...interface{}) LoggerInterface {
 func Panicf(format string, v ...interface{}) LoggerInterface {
 	if loggerInstance.IsPanic() {
 		loggerInstance.printf(LevelPanicName, format, v...)
-		log.Panic("")
+		loggerInstance.getOutput().Panic("")
 	}
 	return loggerInstance
 }
@@ -270,7 +323,7 @@ func SetLogLevelFromString(levelString string) LoggerInterface {
 // Trace() logs a TRACE message.
 func Trace(v ...interface{}) LoggerInterface {
 	if loggerInstance.IsTrace() {
-		loggerInstance.printf(LevelTraceName, noFormat, v...)
+		loggerInstance.print(LevelTraceName, v...)
 	}
 	return loggerInstance
 }
@@ -286,7 +339,7 @@ func Tracef(format string, v ...interface{}) LoggerInterface {
 // Warn() logs a WARN message.
 func Warn(v ...interface{}) LoggerInterface {
 	if loggerInstance.IsWarn() {
-		loggerInstance.printf(LevelWarnName, noFormat, v...)
+		loggerInstance.print(LevelWarnName, v...)
 	}
 	return loggerInstance
 }
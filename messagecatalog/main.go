@@ -0,0 +1,329 @@
+/*
+The messagecatalog package loads message templates, statuses, and level overrides
+from JSON or YAML so they can be maintained outside of Go source code.
+
+A catalog file lists messages by id:
+
+	{
+	    "messages": [
+	        {"id": 2001, "text": "%s knows %s", "arguments": 2, "status": "INFO"},
+	        {"id": 3001, "text": "Record %[1]s not found", "level": "WARN"}
+	    ]
+	}
+
+or, in YAML:
+
+	messages:
+	  - id: 2001
+	    text: "%s knows %s"
+	    arguments: 2
+	    status: INFO
+
+For examples of use, see https://github.com/Senzing/go-logging/blob/main/messagecatalog/messagecatalog_test.go
+*/
+package messagecatalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/senzing/go-logging/logger"
+	"github.com/senzing/go-logging/messagelevel"
+	"github.com/senzing/go-logging/messagestatus"
+	"github.com/senzing/go-logging/messagetext"
+	"gopkg.in/yaml.v3"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The Format type identifies the encoding of a catalog.
+type Format int
+
+// The MessageCatalog type holds validated message templates, statuses, and level overrides by message id.
+type MessageCatalog struct {
+	IdLevels   map[int]logger.Level // Message ids and the corresponding logger level.
+	IdMessages map[int]string       // Message ids and the corresponding format string.
+	IdStatuses map[int]string       // Message ids and the corresponding status.
+}
+
+// A message entry as it appears in a catalog file.
+type catalogEntry struct {
+	Id        *int   `json:"id" yaml:"id"`                                   // Message id, in the range 0..9999.
+	Text      string `json:"text,omitempty" yaml:"text,omitempty"`           // Format string for the "text" field.
+	Arguments *int   `json:"arguments,omitempty" yaml:"arguments,omitempty"` // Expected number of format string arguments.
+	Status    string `json:"status,omitempty" yaml:"status,omitempty"`       // Value for the "status" field.
+	Level     string `json:"level,omitempty" yaml:"level,omitempty"`         // Log level: TRACE, DEBUG, INFO, WARN, ERROR, FATAL, PANIC.
+}
+
+// The contents of a catalog file.
+type catalogFile struct {
+	Messages []catalogEntry `json:"messages" yaml:"messages"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// FormatXxxx values identify the supported catalog encodings.
+const (
+	FormatJson Format = iota
+	FormatYaml
+)
+
+// The range of valid message ids.
+const (
+	MinimumId = 0
+	MaximumId = 9999
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Load function reads a catalog file.
+Files ending in ".yaml" or ".yml" are read as YAML; all others are read as JSON.
+*/
+func Load(filename string) (*MessageCatalog, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, formatFromFilename(filename))
+}
+
+/*
+The LoadFS function reads a catalog file from a file system, such as an embed.FS.
+Files ending in ".yaml" or ".yml" are read as YAML; all others are read as JSON.
+*/
+func LoadFS(fsys fs.FS, filename string) (*MessageCatalog, error) {
+	data, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, formatFromFilename(filename))
+}
+
+/*
+The Parse function decodes and validates a catalog.
+The format verbs of each text are checked, as is the number of arguments if "arguments" is declared.
+Without "arguments", a text must use every argument up to the last one it uses, so "%[2]s" alone is an error.
+All problems found are reported in the returned error.
+*/
+func Parse(data []byte, format Format) (*MessageCatalog, error) {
+	var file catalogFile
+	var err error
+	switch format {
+	case FormatYaml:
+		err = yaml.Unmarshal(data, &file)
+	default:
+		err = json.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode message catalog: %w", err)
+	}
+
+	result := &MessageCatalog{
+		IdLevels:   map[int]logger.Level{},
+		IdMessages: map[int]string{},
+		IdStatuses: map[int]string{},
+	}
+	var errorList []error
+	seen := map[int]bool{}
+	for index, entry := range file.Messages {
+		if entry.Id == nil {
+			errorList = append(errorList, fmt.Errorf("message #%d: missing id", index+1))
+			continue
+		}
+		id := *entry.Id
+		if id < MinimumId || id > MaximumId {
+			errorList = append(errorList, fmt.Errorf("message id %d: outside of range %d..%d", id, MinimumId, MaximumId))
+		}
+		if seen[id] {
+			errorList = append(errorList, fmt.Errorf("message id %d: duplicate id", id))
+			continue
+		}
+		seen[id] = true
+
+		if len(entry.Text) > 0 {
+			isUsed, err := usedArguments(entry.Text)
+			if err != nil {
+				errorList = append(errorList, fmt.Errorf("message id %d: %w", id, err))
+			} else if entry.Arguments != nil && *entry.Arguments != len(isUsed) {
+				errorList = append(errorList, fmt.Errorf("message id %d: text uses %d arguments, but %d declared", id, len(isUsed), *entry.Arguments))
+			} else if entry.Arguments == nil {
+				// Without a declared count, an unused argument is most likely a misnumbered index, e.g. "%[2]s" alone.
+				for argumentIndex, used := range isUsed {
+					if !used {
+						errorList = append(errorList, fmt.Errorf("message id %d: text does not use argument %d of %d", id, argumentIndex+1, len(isUsed)))
+						break
+					}
+				}
+			}
+			result.IdMessages[id] = entry.Text
+		}
+
+		if len(entry.Status) > 0 {
+			result.IdStatuses[id] = entry.Status
+		}
+
+		if len(entry.Level) > 0 {
+			level, ok := logger.TextToLevelMap[strings.ToUpper(entry.Level)]
+			if !ok {
+				errorList = append(errorList, fmt.Errorf("message id %d: unknown level %q", id, entry.Level))
+			} else {
+				result.IdLevels[id] = level
+			}
+		}
+	}
+
+	if len(errorList) > 0 {
+		return nil, errors.Join(errorList...)
+	}
+	return result, nil
+}
+
+/*
+The CountArguments function returns the number of arguments a printf-style format string consumes.
+Explicit argument indexes (e.g. "%[2]s") and "*" widths and precisions are taken into account.
+An error is returned for malformed verbs.
+*/
+func CountArguments(template string) (int, error) {
+	isUsed, err := usedArguments(template)
+	return len(isUsed), err
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Return whether each argument of a printf-style format string is used, up to the last one used.
+See CountArguments().
+*/
+func usedArguments(template string) ([]bool, error) {
+	var err error
+	result := []bool{}
+	argumentNumber := 0
+	useArgument := func() {
+		argumentNumber++
+		for len(result) < argumentNumber {
+			result = append(result, false)
+		}
+		result[argumentNumber-1] = true
+	}
+	length := len(template)
+	for index := 0; index < length; index++ {
+		if template[index] != '%' {
+			continue
+		}
+		index++
+		if index < length && template[index] == '%' {
+			continue
+		}
+
+		// The order follows https://pkg.go.dev/fmt: flags, [index], width, .precision, [index], verb.
+
+		for index < length && strings.IndexByte("+-# 0", template[index]) >= 0 {
+			index++
+		}
+		argumentNumber, index, err = parseArgumentIndex(template, index, argumentNumber)
+		if err != nil {
+			return nil, err
+		}
+		index = skipWidth(template, index, useArgument)
+		if index < length && template[index] == '.' {
+			index++
+			argumentNumber, index, err = parseArgumentIndex(template, index, argumentNumber)
+			if err != nil {
+				return nil, err
+			}
+			index = skipWidth(template, index, useArgument)
+		}
+		argumentNumber, index, err = parseArgumentIndex(template, index, argumentNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		if index >= length {
+			return nil, fmt.Errorf("missing verb at end of %q", template)
+		}
+		if strings.IndexByte("vTtbcdoOqxXUeEfFgGsp", template[index]) < 0 {
+			return nil, fmt.Errorf("unknown verb %%%c in %q", template[index], template)
+		}
+		useArgument()
+	}
+	return result, nil
+}
+
+// Parse an optional "[n]" argument index, returning the argument number that precedes the next argument.
+func parseArgumentIndex(template string, index int, argumentNumber int) (int, int, error) {
+	if index >= len(template) || template[index] != '[' {
+		return argumentNumber, index, nil
+	}
+	end := strings.IndexByte(template[index:], ']')
+	if end < 0 {
+		return argumentNumber, index, fmt.Errorf("unterminated argument index in %q", template)
+	}
+	explicit, err := strconv.Atoi(template[index+1 : index+end])
+	if err != nil || explicit < 1 {
+		return argumentNumber, index, fmt.Errorf("bad argument index %q in %q", template[index:index+end+1], template)
+	}
+	return explicit - 1, index + end + 1, nil
+}
+
+// Skip a width or precision, which is either digits or a "*" that consumes an argument.
+func skipWidth(template string, index int, useArgument func()) int {
+	if index < len(template) && template[index] == '*' {
+		useArgument()
+		return index + 1
+	}
+	for index < len(template) && template[index] >= '0' && template[index] <= '9' {
+		index++
+	}
+	return index
+}
+
+func formatFromFilename(filename string) Format {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return FormatYaml
+	default:
+		return FormatJson
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+// The MessageLevel method returns a MessageLevelSenzing using the catalog's level overrides.
+func (messageCatalog *MessageCatalog) MessageLevel() *messagelevel.MessageLevelSenzing {
+	return &messagelevel.MessageLevelSenzing{
+		DefaultLogLevel: logger.LevelInfo,
+		IdLevels:        messageCatalog.IdLevels,
+		IdLevelRanges:   messagelevel.IdLevelRanges,
+	}
+}
+
+// The MessageStatus method returns a MessageStatusSenzing using the catalog's statuses.
+func (messageCatalog *MessageCatalog) MessageStatus() *messagestatus.MessageStatusSenzing {
+	return &messagestatus.MessageStatusSenzing{
+		IdStatuses: messageCatalog.IdStatuses,
+	}
+}
+
+// The MessageText method returns a MessageTextSenzing using the catalog's message templates.
+func (messageCatalog *MessageCatalog) MessageText() *messagetext.MessageTextSenzing {
+	return &messagetext.MessageTextSenzing{
+		IdMessages: messageCatalog.IdMessages,
+	}
+}
@@ -0,0 +1,172 @@
+package messagecatalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/senzing/go-logging/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+var catalogJson = `{
+    "messages": [
+        {"id": 2001, "text": "%s knows %s", "arguments": 2, "status": "INFO"},
+        {"id": 3001, "text": "Record %[2]s in %[1]s not found", "level": "WARN"},
+        {"id": 4001, "status": "ERROR_retryable"}
+    ]
+}`
+
+var catalogYaml = `
+messages:
+  - id: 2001
+    text: "%s knows %s"
+    arguments: 2
+    status: INFO
+  - id: 3001
+    text: "Record %[2]s in %[1]s not found"
+    level: warn
+  - id: 4001
+    status: ERROR_retryable
+`
+
+var testCasesForCountArguments = []struct {
+	name          string
+	template      string
+	expected      int
+	expectedError bool
+}{
+	{name: "messagecatalog-01-none", template: "No arguments, 100%% sure", expected: 0},
+	{name: "messagecatalog-02-sequential", template: "%s knows %d people", expected: 2},
+	{name: "messagecatalog-03-explicit", template: "%[2]d is %[1]s", expected: 2},
+	{name: "messagecatalog-04-star", template: "%*d and %.*f", expected: 4},
+	{name: "messagecatalog-05-flags", template: "%-10s|%+.2f|%#x", expected: 3},
+	{name: "messagecatalog-06-reuse", template: "%[1]s and %[1]s", expected: 1},
+	{name: "messagecatalog-07-trailing", template: "Trailing %", expectedError: true},
+	{name: "messagecatalog-08-unknown_verb", template: "Bad %y", expectedError: true},
+	{name: "messagecatalog-09-bad_index", template: "Bad %[0]s", expectedError: true},
+	{name: "messagecatalog-10-unterminated_index", template: "Bad %[1s", expectedError: true},
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+func testError(test *testing.T, err error) {
+	if err != nil {
+		assert.Fail(test, err.Error())
+	}
+}
+
+func testCatalog(test *testing.T, messageCatalog *MessageCatalog) {
+	assert.Equal(test, map[int]string{2001: "%s knows %s", 3001: "Record %[2]s in %[1]s not found"}, messageCatalog.IdMessages)
+	assert.Equal(test, map[int]string{2001: "INFO", 4001: "ERROR_retryable"}, messageCatalog.IdStatuses)
+	assert.Equal(test, map[int]logger.Level{3001: logger.LevelWarn}, messageCatalog.IdLevels)
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestParseJson(test *testing.T) {
+	messageCatalog, err := Parse([]byte(catalogJson), FormatJson)
+	testError(test, err)
+	testCatalog(test, messageCatalog)
+}
+
+func TestParseYaml(test *testing.T) {
+	messageCatalog, err := Parse([]byte(catalogYaml), FormatYaml)
+	testError(test, err)
+	testCatalog(test, messageCatalog)
+}
+
+func TestParseErrors(test *testing.T) {
+	catalog := `{
+    "messages": [
+        {"id": 1, "text": "%s"},
+        {"id": 1, "text": "%s"},
+        {"id": 10000},
+        {"text": "No id"},
+        {"id": 2, "text": "%s and %s", "arguments": 1},
+        {"id": 3, "text": "Bad %"},
+        {"id": 4, "level": "LOUD"},
+        {"id": 5, "text": "Only %[2]s"},
+        {"id": 6, "text": "%[2]s and %s"},
+        {"id": 7, "text": "%[2]s knows %[1]s"}
+    ]
+}`
+	_, err := Parse([]byte(catalog), FormatJson)
+	if assert.Error(test, err) {
+		assert.ErrorContains(test, err, "message id 1: duplicate id")
+		assert.ErrorContains(test, err, "message id 10000: outside of range 0..9999")
+		assert.ErrorContains(test, err, "message #4: missing id")
+		assert.ErrorContains(test, err, "message id 2: text uses 2 arguments, but 1 declared")
+		assert.ErrorContains(test, err, "message id 3: missing verb")
+		assert.ErrorContains(test, err, `message id 4: unknown level "LOUD"`)
+		assert.ErrorContains(test, err, "message id 5: text does not use argument 1 of 2")
+		assert.ErrorContains(test, err, "message id 6: text does not use argument 1 of 3")
+		assert.NotContains(test, err.Error(), "message id 7")
+	}
+}
+
+func TestParseBadJson(test *testing.T) {
+	_, err := Parse([]byte(`{"messages": [`), FormatJson)
+	assert.ErrorContains(test, err, "cannot decode message catalog")
+}
+
+func TestLoad(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "messages.yaml")
+	testError(test, os.WriteFile(filename, []byte(catalogYaml), 0600))
+	messageCatalog, err := Load(filename)
+	testError(test, err)
+	testCatalog(test, messageCatalog)
+}
+
+func TestLoadFS(test *testing.T) {
+	fsys := fstest.MapFS{
+		"catalog/messages.json": &fstest.MapFile{Data: []byte(catalogJson)},
+	}
+	messageCatalog, err := LoadFS(fsys, "catalog/messages.json")
+	testError(test, err)
+	testCatalog(test, messageCatalog)
+}
+
+func TestCountArguments(test *testing.T) {
+	for _, testCase := range testCasesForCountArguments {
+		test.Run(testCase.name, func(test *testing.T) {
+			actual, err := CountArguments(testCase.template)
+			if testCase.expectedError {
+				assert.Error(test, err, testCase.name)
+			} else {
+				testError(test, err)
+				assert.Equal(test, testCase.expected, actual, testCase.name)
+			}
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test public methods
+// ----------------------------------------------------------------------------
+
+func TestMessageComponents(test *testing.T) {
+	messageCatalog, err := Parse([]byte(catalogJson), FormatJson)
+	testError(test, err)
+
+	text, err := messageCatalog.MessageText().MessageText(2001, "Bob", "Jane")
+	testError(test, err)
+	assert.Equal(test, "Bob knows Jane", text)
+
+	status, err := messageCatalog.MessageStatus().MessageStatus(4001)
+	testError(test, err)
+	assert.Equal(test, "ERROR_retryable", status)
+
+	level, err := messageCatalog.MessageLevel().MessageLevel(3001)
+	testError(test, err)
+	assert.Equal(test, logger.LevelWarn, level)
+
+	level, err = messageCatalog.MessageLevel().MessageLevel(2001)
+	testError(test, err)
+	assert.Equal(test, logger.LevelInfo, level)
+}
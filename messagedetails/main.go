@@ -1,6 +1,13 @@
 /*
 The messagedetails package produces a value for the "details" field.
 
+Details are keyed by their position ("1", "2", ...) unless they are named.
+Named details keep their key no matter where they appear:
+
+	messageLogger.Log(2001, messagedetails.Pair("recordId", 1001))
+	messageLogger.Log(2001, messagedetails.Pairs("dataSource", "CUSTOMERS", "recordId", 1001))
+	messageLogger.Log(2001, map[string]interface{}{"recordId": 1001})
+
 For examples of use, see https://github.com/Senzing/go-logging/blob/main/messagedetails/messagedetails_test.go
 */
 package messagedetails
@@ -9,6 +16,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // ----------------------------------------------------------------------------
@@ -20,6 +28,51 @@ type MessageDetailsInterface interface {
 	MessageDetails(messageNumber int, details ...interface{}) (interface{}, error) // Get the "details" value from the messageNumber and details.
 }
 
+// The KeyValue type is a detail that appears in the "details" field under Key.
+type KeyValue struct {
+	Key   string      // Key in the "details" field.
+	Value interface{} // Value of the detail.
+}
+
+// The KeyValues type holds alternating keys and values, each pair appearing in the "details" field under its key.
+type KeyValues []interface{}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The key used for a value in KeyValues that has no key.
+const BadKey = "!BADKEY"
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+The Pair function returns a detail that is listed under key.
+Example:
+
+	messageLogger.Log(2001, messagedetails.Pair("recordId", 1001))
+*/
+func Pair(key string, value interface{}) KeyValue {
+	return KeyValue{
+		Key:   key,
+		Value: value,
+	}
+}
+
+/*
+The Pairs function returns details from alternating keys and values.
+Keys that are not strings are formatted with fmt.Sprint().
+A trailing value without a key is listed under BadKey.
+Example:
+
+	messageLogger.Log(2001, messagedetails.Pairs("dataSource", "CUSTOMERS", "recordId", 1001))
+*/
+func Pairs(keysAndValues ...interface{}) KeyValues {
+	return KeyValues(keysAndValues)
+}
+
 // ----------------------------------------------------------------------------
 // Internal functions
 // ----------------------------------------------------------------------------
@@ -43,6 +96,47 @@ func jsonAsInterface(unknownString string) interface{} {
 	return jsonString
 }
 
+// Add named details to result.
+func addKeyValues(result map[string]interface{}, keysAndValues KeyValues) {
+	for index := 0; index < len(keysAndValues); index += 2 {
+		if index+1 == len(keysAndValues) {
+			result[BadKey] = namedValue(keysAndValues[index])
+			break
+		}
+		key, ok := keysAndValues[index].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[index])
+		}
+		result[key] = namedValue(keysAndValues[index+1])
+	}
+}
+
+/*
+Return the "details" representation of a named value.
+Named values keep their type: a string is embedded as JSON only if it is a JSON object or array,
+so "1001" and "true" stay strings.
+*/
+func namedValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case nil:
+		return "<nil>"
+	case bool, int, int64, uint64, float64:
+		return typedValue
+	case string:
+		trimmed := strings.TrimSpace(typedValue)
+		if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && isJson(trimmed) {
+			return jsonAsInterface(trimmed)
+		}
+		return typedValue
+	default:
+		valueAsString := stringify(typedValue)
+		if isJson(valueAsString) {
+			return jsonAsInterface(valueAsString)
+		}
+		return valueAsString
+	}
+}
+
 func stringify(unknown interface{}) string {
 	// See https://pkg.go.dev/fmt for format strings.
 	var result string
@@ -68,3 +162,15 @@ func stringify(unknown interface{}) string {
 	}
 	return result
 }
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Format method formats the value of the KeyValue,
+so a KeyValue can be used as an argument of a message text template.
+*/
+func (keyValue KeyValue) Format(state fmt.State, verb rune) {
+	fmt.Fprintf(state, fmt.FormatString(state, verb), keyValue.Value)
+}
@@ -6,6 +6,8 @@ package messagedetails
 import (
 	"fmt"
 	"strconv"
+
+	"github.com/senzing/go-logging/messagetrace"
 )
 
 // ----------------------------------------------------------------------------
@@ -20,6 +22,7 @@ type MessageDetailsSenzing struct{}
 // ----------------------------------------------------------------------------
 
 // The MessageDetails method returns a map[string]interface{} with un-indexed instances receiving an ordinal index.
+// KeyValue, KeyValues, map[string]string, and map[string]interface{} details are listed under their keys.
 func (messageDetails *MessageDetailsSenzing) MessageDetails(messageNumber int, details ...interface{}) (interface{}, error) {
 	var err error = nil
 
@@ -48,6 +51,23 @@ func (messageDetails *MessageDetailsSenzing) MessageDetails(messageNumber int, d
 		case error:
 			// do nothing.
 
+		case messagetrace.TraceContext:
+			// Reported in the "trace_id" and "span_id" fields.
+
+		case messagetrace.TraceParent:
+			// Reported in the "trace_id" and "span_id" fields if valid, else kept so it is not lost.
+			if _, err := messagetrace.ParseTraceParent(string(typedValue)); err != nil {
+				result[strconv.Itoa(index+1)] = string(typedValue)
+			}
+
+		case KeyValue:
+			result[typedValue.Key] = namedValue(typedValue.Value)
+		case KeyValues:
+			addKeyValues(result, typedValue)
+		case map[string]interface{}:
+			for mapIndex, mapValue := range typedValue {
+				result[mapIndex] = namedValue(mapValue)
+			}
 		case map[string]string:
 			for mapIndex, mapValue := range typedValue {
 				mapValueAsString := stringify(mapValue)
@@ -3,8 +3,10 @@ package messagedetails
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/senzing/go-logging/messagetrace"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -63,6 +65,41 @@ var testCases = []struct {
 		expectedDefault: map[string]interface{}{"1": json.RawMessage(`{"A": {"B": "A JSON example"}}`)},
 		expectedSenzing: map[string]interface{}{"1": json.RawMessage(`{"A": {"B": "A JSON example"}}`)},
 	},
+	{
+		name:            "messagedetails-08",
+		messageNumber:   1008,
+		details:         []interface{}{Pair("recordId", 1001), "A", Pair("active", true)},
+		expectedDefault: map[string]interface{}{"recordId": 1001, "2": "A", "active": true},
+		expectedSenzing: map[string]interface{}{"recordId": 1001, "2": "A", "active": true},
+	},
+	{
+		name:            "messagedetails-09",
+		messageNumber:   1009,
+		details:         []interface{}{Pairs("dataSource", "CUSTOMERS", 7, errors.New("test error"), "odd")},
+		expectedDefault: map[string]interface{}{"dataSource": "CUSTOMERS", "7": "test error", BadKey: "odd"},
+		expectedSenzing: map[string]interface{}{"dataSource": "CUSTOMERS", "7": "test error", BadKey: "odd"},
+	},
+	{
+		name:            "messagedetails-10",
+		messageNumber:   1010,
+		details:         []interface{}{map[string]interface{}{"recordId": 1001, "score": 0.5, "none": nil, "json": `{"A": 1}`, "number": "1001"}},
+		expectedDefault: map[string]interface{}{"recordId": 1001, "score": 0.5, "none": "<nil>", "json": json.RawMessage(`{"A": 1}`), "number": "1001"},
+		expectedSenzing: map[string]interface{}{"recordId": 1001, "score": 0.5, "none": "<nil>", "json": json.RawMessage(`{"A": 1}`), "number": "1001"},
+	},
+	{
+		name:            "messagedetails-11",
+		messageNumber:   1011,
+		details:         []interface{}{"A", messagetrace.TraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"), messagetrace.TraceContext{}},
+		expectedDefault: map[string]interface{}{"1": "A"},
+		expectedSenzing: map[string]interface{}{"1": "A"},
+	},
+	{
+		name:            "messagedetails-12",
+		messageNumber:   1012,
+		details:         []interface{}{"A", messagetrace.TraceParent("00-not-a-traceparent")},
+		expectedDefault: map[string]interface{}{"1": "A", "2": "00-not-a-traceparent"},
+		expectedSenzing: map[string]interface{}{"1": "A", "2": "00-not-a-traceparent"},
+	},
 }
 
 // ----------------------------------------------------------------------------
@@ -123,3 +160,11 @@ func TestMessageDetailsSenzing(test *testing.T) {
 		}
 	}
 }
+
+// ----------------------------------------------------------------------------
+// Test KeyValue
+// ----------------------------------------------------------------------------
+
+func TestKeyValueFormat(test *testing.T) {
+	assert.Equal(test, "Bob has 0042 records", fmt.Sprintf("%s has %04d records", Pair("name", "Bob"), Pair("count", 42)))
+}
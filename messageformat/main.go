@@ -6,8 +6,19 @@ For examples of use, see https://github.com/Senzing/go-logging/blob/main/message
 package messageformat
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 // ----------------------------------------------------------------------------
@@ -19,6 +30,109 @@ type MessageFormatInterface interface {
 	Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) // Create a message.
 }
 
+/*
+The MessageFormatTraceInterface type is implemented by formats with fields for W3C trace context.
+A message logger uses MessageWithTrace() instead of Message() when a message has a trace context.
+*/
+type MessageFormatTraceInterface interface {
+	MessageWithTrace(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}, traceId string, spanId string) (string, error) // Create a message with "trace_id" and "span_id" fields.
+}
+
+/*
+The MessageFormatBinaryInterface type is implemented by formats whose messages are binary and self-delimiting.
+A message logger writing to an io.Writer leaves out the newline that Go's log package adds after these messages,
+so the output is a sequence of messages read by CborStreamAsJson() or MessagePackStreamAsJson().
+*/
+type MessageFormatBinaryInterface interface {
+	MessageLength(data []byte) (int, error) // Return the length of the message at the beginning of data.
+}
+
+// The binaryEncoder type appends the parts of an encoded value: CBOR data items, MessagePack values, or OTLP AnyValues.
+type binaryEncoder interface {
+	appendNil(buffer []byte) []byte
+	appendBool(buffer []byte, value bool) []byte
+	appendInt(buffer []byte, value int64) []byte
+	appendUint(buffer []byte, value uint64) []byte
+	appendFloat(buffer []byte, value float64) []byte
+	appendString(buffer []byte, value string) []byte
+	appendArrayHead(buffer []byte, length int) []byte
+	appendMapHead(buffer []byte, length int) []byte
+}
+
+// How values of a type are encoded with reflection. See binaryTypeOf().
+type binaryType struct {
+	isReflectable bool                // False if values are converted through JSON.
+	fields        []binaryStructField // For a struct, the fields that encoding/json encodes, in order.
+}
+
+// A struct field as encoding/json encodes it.
+type binaryStructField struct {
+	index       int
+	name        string
+	isOmitEmpty bool
+}
+
+// A named value in a binary encoded map, kept in order.
+type messageField struct {
+	key   string
+	value interface{}
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The ANSI escape sequence that ends a color.
+const ansiReset = "\x1b[0m"
+
+// The layout of RFC 3339 timestamps.
+// Message() methods cannot use time.RFC3339Nano directly, as their "time" parameter shadows the package.
+const rfc3339Nano = time.RFC3339Nano
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// AnsiColors maps color names to ANSI escape sequences.
+var AnsiColors = map[string]string{
+	"black":   "\x1b[30m",
+	"red":     "\x1b[31m",
+	"green":   "\x1b[32m",
+	"yellow":  "\x1b[33m",
+	"blue":    "\x1b[34m",
+	"magenta": "\x1b[35m",
+	"cyan":    "\x1b[36m",
+	"white":   "\x1b[37m",
+	"gray":    "\x1b[90m",
+	"bold":    "\x1b[1m",
+	"faint":   "\x1b[2m",
+}
+
+// LevelColors maps "level" field values to names in AnsiColors.
+var LevelColors = map[string]string{
+	"TRACE": "gray",
+	"DEBUG": "blue",
+	"INFO":  "green",
+	"WARN":  "yellow",
+	"ERROR": "red",
+	"FATAL": "magenta",
+	"PANIC": "magenta",
+}
+
+// Types that encoding/json treats specially.
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonNumberType    = reflect.TypeOf(json.Number(""))
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// The binaryType of each reflect.Type, filled in by binaryTypeOf().
+var binaryTypes sync.Map
+
+// The clock for formats that add their own timestamp.
+// Message() methods cannot call time.Now() directly, as their "time" parameter shadows the package.
+var timeNow = time.Now
+
 // ----------------------------------------------------------------------------
 // Internal functions
 // ----------------------------------------------------------------------------
@@ -28,8 +142,7 @@ func isJson(unknownString string) bool {
 	if err != nil {
 		unknownStringUnescaped = unknownString
 	}
-	var jsonString json.RawMessage
-	return json.Unmarshal([]byte(unknownStringUnescaped), &jsonString) == nil
+	return json.Valid([]byte(unknownStringUnescaped))
 }
 
 func jsonAsInterface(unknownString string) interface{} {
@@ -41,3 +154,480 @@ func jsonAsInterface(unknownString string) interface{} {
 	json.Unmarshal([]byte(unknownStringUnescaped), &jsonString)
 	return jsonString
 }
+
+// Wrap text in the ANSI color with the given name. Unknown names leave text unchanged.
+func colorize(name string, text string) string {
+	ansiColor, ok := AnsiColors[name]
+	if !ok || len(text) == 0 {
+		return text
+	}
+	return ansiColor + text + ansiReset
+}
+
+// Return JSON without HTML escaping.
+func encodeJson(value interface{}) (string, error) {
+	var resultBytes bytes.Buffer
+	enc := json.NewEncoder(&resultBytes)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(value)
+	return strings.TrimSpace(resultBytes.String()), err
+}
+
+/*
+Return the time of a message from its "date" and "time" fields, as produced by
+messagedate.MessageDateSenzing and messagetime.MessageTimeSenzing.
+If they are missing or in another format, the current time is returned.
+*/
+func messageTimestamp(date string, clock string) time.Time {
+	result, err := time.Parse("2006-01-02 15:04:05.999999999", date+" "+clock)
+	if err != nil {
+		return timeNow().UTC()
+	}
+	return result
+}
+
+/*
+Return the fields of MessageFormatSenzing in its order: date, time, level, id, text, status, duration,
+location, trace_id, span_id, errors, details. Empty fields are left out.
+*/
+func senzingFields(date string, clock string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}, traceId string, spanId string) []messageField {
+	result := make([]messageField, 0, 12)
+	for _, field := range []messageField{
+		{"date", date},
+		{"time", clock},
+		{"level", level},
+		{"id", id},
+	} {
+		if len(field.value.(string)) > 0 {
+			result = append(result, field)
+		}
+	}
+	if len(text) > 0 {
+		if isJson(text) {
+			result = append(result, messageField{"text", jsonAsInterface(text)})
+		} else {
+			result = append(result, messageField{"text", text})
+		}
+	}
+	if len(status) > 0 {
+		result = append(result, messageField{"status", status})
+	}
+	if duration != 0 {
+		result = append(result, messageField{"duration", duration})
+	}
+	if len(location) > 0 {
+		result = append(result, messageField{"location", location})
+	}
+	if len(traceId) > 0 {
+		result = append(result, messageField{"trace_id", traceId})
+	}
+	if len(spanId) > 0 {
+		result = append(result, messageField{"span_id", spanId})
+	}
+	if !isNil(errors) {
+		result = append(result, messageField{"errors", errors})
+	}
+	if !isNil(details) {
+		result = append(result, messageField{"details", details})
+	}
+	return result
+}
+
+// Return the float64 with the shortest decimal of a float32, as encoding/json writes it.
+func float32Value(value float64) float64 {
+	result, err := strconv.ParseFloat(strconv.FormatFloat(value, 'g', -1, 32), 64)
+	if err != nil {
+		return value
+	}
+	return result
+}
+
+// Return true if encoding/json uses methods of reflectType, rather than its kind, to encode it.
+func isJsonMarshaler(reflectType reflect.Type) bool {
+	for _, candidate := range []reflect.Type{reflectType, reflect.PointerTo(reflectType)} {
+		if candidate.Implements(jsonMarshalerType) || candidate.Implements(textMarshalerType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Return true if encoding/json leaves out a field with the "omitempty" option and this value.
+func isEmptyValue(reflectValue reflect.Value) bool {
+	switch reflectValue.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return reflectValue.Len() == 0
+	case reflect.Bool:
+		return !reflectValue.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflectValue.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflectValue.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return reflectValue.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return reflectValue.IsNil()
+	}
+	return false
+}
+
+/*
+Return how values of reflectType are encoded with reflection, following the rules of encoding/json.
+Values are converted through JSON instead if they are json.Marshaler or encoding.TextMarshaler implementations,
+maps without string keys, structs with embedded fields or the "string" field option, or kinds that JSON cannot encode.
+*/
+func binaryTypeOf(reflectType reflect.Type) *binaryType {
+	if cached, ok := binaryTypes.Load(reflectType); ok {
+		return cached.(*binaryType)
+	}
+	result := &binaryType{isReflectable: !isJsonMarshaler(reflectType)}
+	switch reflectType.Kind() {
+	case reflect.Chan, reflect.Complex64, reflect.Complex128, reflect.Func, reflect.UnsafePointer:
+		result.isReflectable = false
+	case reflect.Map:
+		result.isReflectable = result.isReflectable && reflectType.Key().Kind() == reflect.String && !isJsonMarshaler(reflectType.Key())
+	case reflect.Struct:
+		for index := 0; index < reflectType.NumField() && result.isReflectable; index++ {
+			field := reflectType.Field(index)
+			if field.Anonymous {
+				result.isReflectable = false
+				break
+			}
+			tag := field.Tag.Get("json")
+			if !field.IsExported() || tag == "-" {
+				continue
+			}
+			name, options, _ := strings.Cut(tag, ",")
+			if len(name) == 0 {
+				name = field.Name
+			}
+			structField := binaryStructField{index: index, name: name}
+			for _, option := range strings.Split(options, ",") {
+				switch option {
+				case "omitempty":
+					structField.isOmitEmpty = true
+				case "string":
+					result.isReflectable = false
+				}
+			}
+			result.fields = append(result.fields, structField)
+		}
+	}
+	binaryTypes.Store(reflectType, result)
+	return result
+}
+
+// Return true if encoding/json encodes a slice of reflectType as a base64 string.
+func isByteSlice(reflectType reflect.Type) bool {
+	return reflectType.Elem().Kind() == reflect.Uint8 && !isJsonMarshaler(reflectType.Elem())
+}
+
+// Return the keys of a map with string keys in sorted order.
+func sortedMapKeys(reflectValue reflect.Value) []reflect.Value {
+	keys := reflectValue.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
+/*
+Append a value in a binary encoding.
+Maps, slices, arrays, structs, and pointers are encoded with reflection, following the rules of encoding/json.
+As with encoding/json, map keys are sorted, while JSON objects and structs keep their order.
+*/
+func appendBinary(buffer []byte, encoder binaryEncoder, value interface{}) []byte {
+	switch typedValue := value.(type) {
+	case nil:
+		return encoder.appendNil(buffer)
+	case bool:
+		return encoder.appendBool(buffer, typedValue)
+	case int:
+		return encoder.appendInt(buffer, int64(typedValue))
+	case int64:
+		return encoder.appendInt(buffer, typedValue)
+	case uint64:
+		return encoder.appendUint(buffer, typedValue)
+	case float64:
+		return encoder.appendFloat(buffer, typedValue)
+	case string:
+		return encoder.appendString(buffer, typedValue)
+	case json.Number:
+		return appendJsonNumber(buffer, encoder, typedValue)
+	case []interface{}:
+		buffer = encoder.appendArrayHead(buffer, len(typedValue))
+		for _, element := range typedValue {
+			buffer = appendBinary(buffer, encoder, element)
+		}
+		return buffer
+	case []messageField:
+		buffer = encoder.appendMapHead(buffer, len(typedValue))
+		for _, field := range typedValue {
+			buffer = encoder.appendString(buffer, field.key)
+			buffer = appendBinary(buffer, encoder, field.value)
+		}
+		return buffer
+	case map[string]interface{}:
+		keys, _ := sortedDetails(typedValue)
+		buffer = encoder.appendMapHead(buffer, len(keys))
+		for _, key := range keys {
+			buffer = encoder.appendString(buffer, key)
+			buffer = appendBinary(buffer, encoder, typedValue[key])
+		}
+		return buffer
+	}
+	return appendBinaryReflect(buffer, encoder, reflect.ValueOf(value))
+}
+
+// Append a value in a binary encoding with reflection, or through JSON if binaryTypeOf() requires it.
+func appendBinaryReflect(buffer []byte, encoder binaryEncoder, reflectValue reflect.Value) []byte {
+	if !reflectValue.IsValid() {
+		return encoder.appendNil(buffer)
+	}
+	reflectType := reflectValue.Type()
+	valueType := binaryTypeOf(reflectType)
+	if !valueType.isReflectable {
+		data, err := json.Marshal(reflectValue.Interface())
+		if err != nil {
+			return encoder.appendString(buffer, valueAsString(reflectValue.Interface()))
+		}
+		return appendBinary(buffer, encoder, orderedJsonValue(data))
+	}
+	switch reflectValue.Kind() {
+	case reflect.Bool:
+		return encoder.appendBool(buffer, reflectValue.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return encoder.appendInt(buffer, reflectValue.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return encoder.appendUint(buffer, reflectValue.Uint())
+	case reflect.Float32:
+		return encoder.appendFloat(buffer, float32Value(reflectValue.Float()))
+	case reflect.Float64:
+		return encoder.appendFloat(buffer, reflectValue.Float())
+	case reflect.String:
+		if reflectType == jsonNumberType {
+			return appendJsonNumber(buffer, encoder, json.Number(reflectValue.String()))
+		}
+		return encoder.appendString(buffer, reflectValue.String())
+	case reflect.Interface, reflect.Pointer:
+		if reflectValue.IsNil() {
+			return encoder.appendNil(buffer)
+		}
+		return appendBinaryReflect(buffer, encoder, reflectValue.Elem())
+	case reflect.Slice, reflect.Array:
+		if reflectValue.Kind() == reflect.Slice {
+			if reflectValue.IsNil() {
+				return encoder.appendNil(buffer)
+			}
+			if isByteSlice(reflectType) {
+				return encoder.appendString(buffer, base64.StdEncoding.EncodeToString(reflectValue.Bytes()))
+			}
+		}
+		buffer = encoder.appendArrayHead(buffer, reflectValue.Len())
+		for index := 0; index < reflectValue.Len(); index++ {
+			buffer = appendBinaryReflect(buffer, encoder, reflectValue.Index(index))
+		}
+		return buffer
+	case reflect.Map:
+		if reflectValue.IsNil() {
+			return encoder.appendNil(buffer)
+		}
+		keys := sortedMapKeys(reflectValue)
+		buffer = encoder.appendMapHead(buffer, len(keys))
+		for _, key := range keys {
+			buffer = encoder.appendString(buffer, key.String())
+			buffer = appendBinaryReflect(buffer, encoder, reflectValue.MapIndex(key))
+		}
+		return buffer
+	case reflect.Struct:
+		length := 0
+		for _, field := range valueType.fields {
+			if !field.isOmitEmpty || !isEmptyValue(reflectValue.Field(field.index)) {
+				length++
+			}
+		}
+		buffer = encoder.appendMapHead(buffer, length)
+		for _, field := range valueType.fields {
+			fieldValue := reflectValue.Field(field.index)
+			if !field.isOmitEmpty || !isEmptyValue(fieldValue) {
+				buffer = encoder.appendString(buffer, field.name)
+				buffer = appendBinaryReflect(buffer, encoder, fieldValue)
+			}
+		}
+		return buffer
+	}
+	return encoder.appendNil(buffer)
+}
+
+// Append a JSON number as an integer if it is one, else as a float.
+func appendJsonNumber(buffer []byte, encoder binaryEncoder, number json.Number) []byte {
+	if result, err := number.Int64(); err == nil {
+		return encoder.appendInt(buffer, result)
+	}
+	if result, err := strconv.ParseUint(string(number), 10, 64); err == nil {
+		return encoder.appendUint(buffer, result)
+	}
+	if result, err := number.Float64(); err == nil {
+		return encoder.appendFloat(buffer, result)
+	}
+	return encoder.appendString(buffer, string(number))
+}
+
+// Return JSON as a value for binary encoding, keeping the order of object members.
+func orderedJsonValue(data []byte) interface{} {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	result, err := decodeOrderedJson(decoder)
+	if err != nil {
+		return string(data)
+	}
+	return result
+}
+
+// Decode the next JSON value: objects become []messageField and numbers json.Number.
+func decodeOrderedJson(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('['):
+		result := []interface{}{}
+		for decoder.More() {
+			element, err := decodeOrderedJson(decoder)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, element)
+		}
+		_, err = decoder.Token()
+		return result, err
+	case json.Delim('{'):
+		result := []messageField{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrderedJson(decoder)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, messageField{key.(string), value})
+		}
+		_, err = decoder.Token()
+		return result, err
+	}
+	return token, nil
+}
+
+/*
+Write each message read from reader as a line of JSON, using writeAsJson to convert a message.
+A newline between messages, as added by Go's log package, is skipped.
+This is unambiguous, as every message is a map and a top-level newline byte cannot begin one.
+*/
+func writeStreamAsJson(reader io.Reader, writer io.Writer, writeAsJson func(*strings.Builder, []byte, int) (int, error), errTruncated error) error {
+	var data []byte
+	buffer := make([]byte, 64*1024)
+	isEndOfFile := false
+	for {
+		for len(data) > 0 && data[0] == '\n' {
+			data = data[1:]
+		}
+		if len(data) > 0 {
+			var builder strings.Builder
+			offset, err := writeAsJson(&builder, data, 0)
+			if err == nil {
+				builder.WriteString("\n")
+				if _, err = io.WriteString(writer, builder.String()); err != nil {
+					return err
+				}
+				data = data[offset:]
+				continue
+			}
+			if isEndOfFile || !errors.Is(err, errTruncated) {
+				return err
+			}
+		} else if isEndOfFile {
+			return nil
+		}
+		count, err := reader.Read(buffer)
+		data = append(data, buffer[:count]...)
+		if err == io.EOF {
+			isEndOfFile = true
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// Write a float as encoding/json does.
+func writeJsonFloat(builder *strings.Builder, value float64) error {
+	result, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	builder.Write(result)
+	return nil
+}
+
+// Write a string as JSON without HTML escaping.
+func writeJsonString(builder *strings.Builder, value string) error {
+	result, err := encodeJson(value)
+	if err != nil {
+		return err
+	}
+	builder.WriteString(result)
+	return nil
+}
+
+// Return true if a field value is nil or a nil map, slice, or pointer.
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer, reflect.Interface:
+		return reflectValue.IsNil()
+	}
+	return false
+}
+
+// Return the keys of a "details" value in sorted order, with the value of each key.
+func sortedDetails(details interface{}) ([]string, map[string]interface{}) {
+	detailsMap, ok := details.(map[string]interface{})
+	if !ok || len(detailsMap) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(detailsMap))
+	for key := range detailsMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, detailsMap
+}
+
+// Return a field value as a string. JSON strings are unquoted and other JSON is kept as is.
+func valueAsString(value interface{}) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typedValue
+	case json.RawMessage:
+		var unquoted string
+		if json.Unmarshal(typedValue, &unquoted) == nil {
+			return unquoted
+		}
+		return string(typedValue)
+	case fmt.Stringer:
+		return typedValue.String()
+	case error:
+		return typedValue.Error()
+	}
+	if reflectValue := reflect.ValueOf(value); reflectValue.Kind() == reflect.Map || reflectValue.Kind() == reflect.Slice || reflectValue.Kind() == reflect.Struct || reflectValue.Kind() == reflect.Pointer {
+		if result, err := json.Marshal(value); err == nil {
+			return string(result)
+		}
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,353 @@
+/*
+The MessageFormatCbor implementation returns a message in the Concise Binary Object Representation (CBOR)
+(https://www.rfc-editor.org/rfc/rfc8949).
+*/
+package messageformat
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The MessageFormatCbor type is for creating messages in CBOR.
+The message is a map with the fields of MessageFormatSenzing, in the same order.
+Use CborAsJson() to convert a message to the JSON that MessageFormatSenzing creates,
+and CborStreamAsJson() for a file or stream of messages.
+*/
+type MessageFormatCbor struct{}
+
+// The cborEncoder type is the binaryEncoder for CBOR.
+type cborEncoder struct{}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// CBOR major types.
+const (
+	cborUnsignedInteger = 0
+	cborNegativeInteger = 1
+	cborByteString      = 2
+	cborTextString      = 3
+	cborArray           = 4
+	cborMap             = 5
+	cborTag             = 6
+	cborSimple          = 7
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// The error wrapped when CBOR data ends within a data item.
+var errCborTruncated = errors.New("truncated CBOR data")
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Append the head of a data item: the major type and its argument.
+func appendCborHead(buffer []byte, majorType byte, argument uint64) []byte {
+	switch {
+	case argument < 24:
+		return append(buffer, majorType<<5|byte(argument))
+	case argument <= math.MaxUint8:
+		return append(buffer, majorType<<5|24, byte(argument))
+	case argument <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buffer, majorType<<5|25), uint16(argument))
+	case argument <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buffer, majorType<<5|26), uint32(argument))
+	}
+	return binary.BigEndian.AppendUint64(append(buffer, majorType<<5|27), argument)
+}
+
+// Return the argument of the data item whose head begins at offset, and the offset after the head.
+func readCborArgument(data []byte, offset int) (uint64, int, error) {
+	information := data[offset] & 0x1f
+	offset++
+	size := 0
+	switch {
+	case information < 24:
+		return uint64(information), offset, nil
+	case information == 24:
+		size = 1
+	case information == 25:
+		size = 2
+	case information == 26:
+		size = 4
+	case information == 27:
+		size = 8
+	default:
+		return 0, offset, fmt.Errorf("unsupported CBOR additional information %d at offset %d", information, offset-1)
+	}
+	if len(data)-offset < size {
+		return 0, offset, fmt.Errorf("%w at offset %d", errCborTruncated, offset)
+	}
+	var result uint64
+	for _, value := range data[offset : offset+size] {
+		result = result<<8 | uint64(value)
+	}
+	return result, offset + size, nil
+}
+
+// Return the offset after the data item beginning at offset.
+func skipCbor(data []byte, offset int) (int, error) {
+	if offset >= len(data) {
+		return offset, fmt.Errorf("%w at offset %d", errCborTruncated, offset)
+	}
+	majorType := data[offset] >> 5
+	if majorType == cborSimple && data[offset]&0x1f < 24 {
+		return offset + 1, nil
+	}
+	argument, offset, err := readCborArgument(data, offset)
+	if err != nil {
+		return offset, err
+	}
+	switch majorType {
+	case cborByteString, cborTextString:
+		if uint64(len(data)-offset) < argument {
+			return offset, fmt.Errorf("%w at offset %d", errCborTruncated, offset)
+		}
+		return offset + int(argument), nil
+	case cborArray, cborMap:
+		count := argument
+		if majorType == cborMap {
+			count *= 2
+		}
+		for ; count > 0; count-- {
+			if offset, err = skipCbor(data, offset); err != nil {
+				return offset, err
+			}
+		}
+	case cborTag:
+		return skipCbor(data, offset)
+	}
+	return offset, nil
+}
+
+// Return the value of an IEEE 754 half-precision float.
+func float16AsFloat64(bits uint16) float64 {
+	exponent := int(bits>>10) & 0x1f
+	mantissa := float64(bits & 0x3ff)
+	var result float64
+	switch exponent {
+	case 0:
+		result = math.Ldexp(mantissa, -24)
+	case 0x1f:
+		if mantissa == 0 {
+			result = math.Inf(1)
+		} else {
+			result = math.NaN()
+		}
+	default:
+		result = math.Ldexp(mantissa+1024, exponent-25)
+	}
+	if bits&0x8000 != 0 {
+		return -result
+	}
+	return result
+}
+
+// Write the data item beginning at offset as JSON and return the offset after it.
+func writeCborAsJson(builder *strings.Builder, data []byte, offset int) (int, error) {
+	if offset >= len(data) {
+		return offset, fmt.Errorf("%w at offset %d", errCborTruncated, offset)
+	}
+	majorType := data[offset] >> 5
+	information := data[offset] & 0x1f
+	if majorType == cborSimple {
+		switch information {
+		case 20:
+			builder.WriteString("false")
+			return offset + 1, nil
+		case 21:
+			builder.WriteString("true")
+			return offset + 1, nil
+		case 22, 23:
+			builder.WriteString("null")
+			return offset + 1, nil
+		case 25, 26, 27:
+			bits, next, err := readCborArgument(data, offset)
+			if err != nil {
+				return next, err
+			}
+			var value float64
+			switch information {
+			case 25:
+				value = float16AsFloat64(uint16(bits))
+			case 26:
+				value = float64(math.Float32frombits(uint32(bits)))
+			default:
+				value = math.Float64frombits(bits)
+			}
+			return next, writeJsonFloat(builder, value)
+		}
+		return offset, fmt.Errorf("unsupported CBOR simple value %d at offset %d", information, offset)
+	}
+
+	argument, offset, err := readCborArgument(data, offset)
+	if err != nil {
+		return offset, err
+	}
+	switch majorType {
+	case cborUnsignedInteger:
+		builder.WriteString(strconv.FormatUint(argument, 10))
+	case cborNegativeInteger:
+		if argument > math.MaxInt64 {
+			return offset, fmt.Errorf("CBOR negative integer out of range at offset %d", offset)
+		}
+		builder.WriteString(strconv.FormatInt(-1-int64(argument), 10))
+	case cborByteString, cborTextString:
+		if uint64(len(data)-offset) < argument {
+			return offset, fmt.Errorf("%w at offset %d", errCborTruncated, offset)
+		}
+		value := data[offset : offset+int(argument)]
+		offset += int(argument)
+		if majorType == cborByteString {
+			return offset, writeJsonString(builder, base64.StdEncoding.EncodeToString(value))
+		}
+		return offset, writeJsonString(builder, string(value))
+	case cborArray:
+		builder.WriteString("[")
+		for index := uint64(0); index < argument; index++ {
+			if index > 0 {
+				builder.WriteString(",")
+			}
+			if offset, err = writeCborAsJson(builder, data, offset); err != nil {
+				return offset, err
+			}
+		}
+		builder.WriteString("]")
+	case cborMap:
+		builder.WriteString("{")
+		for index := uint64(0); index < argument; index++ {
+			if index > 0 {
+				builder.WriteString(",")
+			}
+			var key strings.Builder
+			if offset, err = writeCborAsJson(&key, data, offset); err != nil {
+				return offset, err
+			}
+			if strings.HasPrefix(key.String(), `"`) {
+				builder.WriteString(key.String())
+			} else if err = writeJsonString(builder, key.String()); err != nil {
+				return offset, err
+			}
+			builder.WriteString(":")
+			if offset, err = writeCborAsJson(builder, data, offset); err != nil {
+				return offset, err
+			}
+		}
+		builder.WriteString("}")
+	case cborTag:
+		return writeCborAsJson(builder, data, offset)
+	}
+	return offset, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+func (encoder cborEncoder) appendNil(buffer []byte) []byte {
+	return append(buffer, 0xf6)
+}
+
+func (encoder cborEncoder) appendBool(buffer []byte, value bool) []byte {
+	if value {
+		return append(buffer, 0xf5)
+	}
+	return append(buffer, 0xf4)
+}
+
+func (encoder cborEncoder) appendInt(buffer []byte, value int64) []byte {
+	if value < 0 {
+		return appendCborHead(buffer, cborNegativeInteger, uint64(-1-value))
+	}
+	return appendCborHead(buffer, cborUnsignedInteger, uint64(value))
+}
+
+func (encoder cborEncoder) appendUint(buffer []byte, value uint64) []byte {
+	return appendCborHead(buffer, cborUnsignedInteger, value)
+}
+
+func (encoder cborEncoder) appendFloat(buffer []byte, value float64) []byte {
+	return binary.BigEndian.AppendUint64(append(buffer, 0xfb), math.Float64bits(value))
+}
+
+func (encoder cborEncoder) appendString(buffer []byte, value string) []byte {
+	return append(appendCborHead(buffer, cborTextString, uint64(len(value))), value...)
+}
+
+func (encoder cborEncoder) appendArrayHead(buffer []byte, length int) []byte {
+	return appendCborHead(buffer, cborArray, uint64(length))
+}
+
+func (encoder cborEncoder) appendMapHead(buffer []byte, length int) []byte {
+	return appendCborHead(buffer, cborMap, uint64(length))
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The CborAsJson function converts a message created by MessageFormatCbor
+to the JSON that MessageFormatSenzing creates for the same message.
+Byte strings become base64 strings and tags are ignored.
+*/
+func CborAsJson(message string) (string, error) {
+	var builder strings.Builder
+	offset, err := writeCborAsJson(&builder, []byte(message), 0)
+	if err != nil {
+		return "", err
+	}
+	if offset != len(message) {
+		return "", fmt.Errorf("unexpected data after CBOR message at offset %d", offset)
+	}
+	return builder.String(), nil
+}
+
+/*
+The CborStreamAsJson function reads messages created by MessageFormatCbor from reader
+and writes each to writer as a line of the JSON that MessageFormatSenzing creates.
+The messages are a CBOR sequence (https://www.rfc-editor.org/rfc/rfc8742), as a message logger writes them.
+A newline after a message, as added by Go's log package, is skipped.
+Example:
+
+	file, _ := os.Open("messages.cbor")
+	err := messageformat.CborStreamAsJson(file, os.Stdout)
+*/
+func CborStreamAsJson(reader io.Reader, writer io.Writer) error {
+	return writeStreamAsJson(reader, writer, writeCborAsJson, errCborTruncated)
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The MessageLength method returns the length of the CBOR encoded message at the beginning of data.
+func (messageFormat *MessageFormatCbor) MessageLength(data []byte) (int, error) {
+	return skipCbor(data, 0)
+}
+
+// The Message method creates a CBOR encoded message.
+func (messageFormat *MessageFormatCbor) Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) {
+	return messageFormat.MessageWithTrace(date, time, level, location, id, status, text, duration, errors, details, "", "")
+}
+
+// The MessageWithTrace method creates a CBOR encoded message with "trace_id" and "span_id" fields.
+func (messageFormat *MessageFormatCbor) MessageWithTrace(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}, traceId string, spanId string) (string, error) {
+	return string(appendBinary(nil, cborEncoder{}, senzingFields(date, time, level, location, id, status, text, duration, errors, details, traceId, spanId))), nil
+}
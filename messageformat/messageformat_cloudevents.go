@@ -0,0 +1,107 @@
+/*
+The MessageFormatCloudEvents implementation returns a message as a CloudEvents 1.0 event in JSON
+(https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/formats/json-format.md).
+*/
+package messageformat
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The MessageFormatCloudEvents type is for creating CloudEvents, e.g.
+
+	{"specversion":"1.0","id":"9f1c...","source":"/loader","type":"com.senzing.log.senzing-99990004","datacontenttype":"application/json","time":"2000-01-01T00:00:00.000001Z","data":{"date":"2000-01-01",...}}
+
+The "id" attribute is a random UUID, unique to each event.
+The "type" attribute is TypePrefix followed by a period and the "id" field of the message.
+The "time" attribute is the message's "date" and "time", or the current time if they are missing.
+The "data" attribute is the message as created by MessageFormatSenzing.
+*/
+type MessageFormatCloudEvents struct {
+	Source     string // "source" attribute, a URI reference. If empty, "/" followed by the program name is used.
+	TypePrefix string // Beginning of the "type" attribute. If empty, CloudEventsTypePrefix is used.
+}
+
+// Attributes of the event.
+// Order is important.
+type messageFormatCloudEvents struct {
+	SpecVersion     string          `json:"specversion"`
+	Id              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	DataContentType string          `json:"datacontenttype"`
+	Time            string          `json:"time"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The CloudEvents specification version in the "specversion" attribute.
+const CloudEventsSpecVersion = "1.0"
+
+// The beginning of the "type" attribute when TypePrefix is empty.
+const CloudEventsTypePrefix = "com.senzing.log"
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return a random (version 4) UUID.
+func newUuid() (string, error) {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[:]); err != nil {
+		return "", err
+	}
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16]), nil
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Message method creates a CloudEvent in JSON.
+func (messageFormat *MessageFormatCloudEvents) Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) {
+	source := messageFormat.Source
+	if len(source) == 0 {
+		source = "/" + filepath.Base(os.Args[0])
+	}
+	eventType := messageFormat.TypePrefix
+	if len(eventType) == 0 {
+		eventType = CloudEventsTypePrefix
+	}
+	if len(id) > 0 {
+		eventType = eventType + "." + id
+	}
+
+	data, err := (&MessageFormatSenzing{}).Message(date, time, level, location, id, status, text, duration, errors, details)
+	if err != nil {
+		return "", err
+	}
+	eventId, err := newUuid()
+	if err != nil {
+		return "", err
+	}
+
+	return encodeJson(&messageFormatCloudEvents{
+		SpecVersion:     CloudEventsSpecVersion,
+		Id:              eventId,
+		Source:          source,
+		Type:            eventType,
+		DataContentType: "application/json",
+		Time:            messageTimestamp(date, time).Format(rfc3339Nano),
+		Data:            json.RawMessage(data),
+	})
+}
@@ -0,0 +1,159 @@
+/*
+The MessageFormatConsole implementation returns a message laid out for people reading a terminal.
+*/
+package messageformat
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The MessageFormatConsole type is for creating messages for a developer's terminal, e.g.
+
+	2000-01-01 00:00:00 INFO  senzing-99990001: Bob knows Jane  In main() at main.go:12
+	    details:
+	        1: Bob
+	        2: Jane
+	    errors:
+	        - error #1
+
+The level is colored and the location dimmed when Output is a terminal,
+unless NoColor is set or the NO_COLOR environment variable is not empty (https://no-color.org/).
+A message logger given an io.Writer, or messagelogger.NewWriterSink(), sets an empty Output to that writer.
+*/
+type MessageFormatConsole struct {
+	Output  io.Writer // Destination of the messages, used to decide on color. If nil, os.Stderr is used, as by Go's standard logger.
+	NoColor bool      // If true, color is not used.
+	once    sync.Once
+	isColor bool
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The IsColorTerminal function returns true if output is a terminal and the NO_COLOR environment variable is empty.
+A terminal is an *os.File that is a character device.
+*/
+func IsColorTerminal(output io.Writer) bool {
+	if len(os.Getenv("NO_COLOR")) > 0 {
+		return false
+	}
+	file, ok := output.(*os.File)
+	if !ok {
+		return false
+	}
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return fileInfo.Mode()&os.ModeCharDevice != 0
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Append an indented "name:" block listing the keys of a map, or the elements of a slice.
+func appendConsoleBlock(builder *strings.Builder, name string, value interface{}, color func(string, string) string) {
+	builder.WriteString("\n    ")
+	builder.WriteString(name)
+	builder.WriteString(":")
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typedValue))
+		for key := range typedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(builder, "\n        %s: %s", color("cyan", key), consoleValue(typedValue[key]))
+		}
+	case []interface{}:
+		for _, element := range typedValue {
+			fmt.Fprintf(builder, "\n        - %s", consoleValue(element))
+		}
+	default:
+		fmt.Fprintf(builder, " %s", consoleValue(typedValue))
+	}
+}
+
+// Return a value for the console. An object with only a "text" member, as in "errors", is shown as its text.
+func consoleValue(value interface{}) string {
+	if object, ok := value.(map[string]interface{}); ok && len(object) == 1 {
+		if text, ok := object["text"]; ok {
+			return consoleValue(text)
+		}
+	}
+	if value == nil {
+		return "null"
+	}
+	return valueAsString(value)
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Message method creates a message for the console.
+func (messageFormat *MessageFormatConsole) Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) {
+	messageFormat.once.Do(func() {
+		output := messageFormat.Output
+		if output == nil {
+			output = os.Stderr
+		}
+		messageFormat.isColor = IsColorTerminal(output)
+	})
+	color := func(name string, text string) string {
+		return text
+	}
+	if messageFormat.isColor && !messageFormat.NoColor {
+		color = colorize
+	}
+
+	var fields []string
+	if len(date) > 0 {
+		fields = append(fields, date)
+	}
+	if len(time) > 0 {
+		fields = append(fields, time)
+	}
+	if len(level) > 0 {
+		fields = append(fields, color(LevelColors[strings.ToUpper(level)], fmt.Sprintf("%-5s", level)))
+	}
+	if len(id) > 0 {
+		fields = append(fields, id+":")
+	}
+	if len(status) > 0 {
+		fields = append(fields, "("+status+")")
+	}
+	if len(text) > 0 {
+		fields = append(fields, text)
+	}
+	if duration != 0 {
+		fields = append(fields, fmt.Sprintf("[%dns]", duration))
+	}
+	if len(location) > 0 {
+		fields = append(fields, color("faint", location))
+	}
+
+	var builder strings.Builder
+	builder.WriteString(strings.Join(fields, " "))
+	if !isNil(details) {
+		appendConsoleBlock(&builder, "details", asJsonValue(details), color)
+	}
+	if !isNil(errors) {
+		appendConsoleBlock(&builder, "errors", asJsonValue(errors), color)
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,182 @@
+/*
+The MessageFormatEcs implementation returns a message in JSON using Elastic Common Schema (ECS) field names
+(https://www.elastic.co/guide/en/ecs/current/index.html).
+*/
+package messageformat
+
+import (
+	"strings"
+
+	"github.com/senzing/go-logging/messagelocation"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The MessageFormatEcs type is for creating messages for Elasticsearch, e.g.
+
+	{"@timestamp":"2000-01-01T00:00:00.123Z","log.level":"INFO","message":"Bob knows Jane","ecs.version":"8.11.0","event":{"code":"senzing-99990001"},"senzing":{"details":{"1":"Bob","2":"Jane"}}}
+
+The fields are mapped as:
+
+  - "date" and "time" to @timestamp, or the current time if they are missing
+  - "level" to log.level
+  - "text" to message
+  - "id" to event.code
+  - "duration" to event.duration, in nanoseconds
+  - "location" to log.origin.function, log.origin.file.name, and log.origin.file.line
+  - "errors" to error.message, and "status" to error.type when there are errors
+  - "status", "details", and a "location" that cannot be parsed to members of the Namespace object
+*/
+type MessageFormatEcs struct {
+	Namespace string // Name of the object holding fields without an ECS equivalent. If empty, EcsNamespace is used.
+}
+
+// Fields in the formatted message.
+// Order is important.
+type messageFormatEcs struct {
+	Timestamp  string           `json:"@timestamp"`
+	LogLevel   string           `json:"log.level,omitempty"`
+	Message    string           `json:"message,omitempty"`
+	EcsVersion string           `json:"ecs.version"`
+	Event      *messageEcsEvent `json:"event,omitempty"`
+	Log        *messageEcsLog   `json:"log,omitempty"`
+	Error      *messageEcsError `json:"error,omitempty"`
+}
+
+type messageEcsEvent struct {
+	Code     string `json:"code,omitempty"`
+	Duration int64  `json:"duration,omitempty"`
+}
+
+type messageEcsLog struct {
+	Origin messageEcsOrigin `json:"origin"`
+}
+
+type messageEcsOrigin struct {
+	File     messageEcsFile `json:"file"`
+	Function string         `json:"function,omitempty"`
+}
+
+type messageEcsFile struct {
+	Name string `json:"name,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+type messageEcsError struct {
+	Message interface{} `json:"message,omitempty"`
+	Type    string      `json:"type,omitempty"`
+}
+
+// Fields without an ECS equivalent.
+type messageEcsNamespace struct {
+	Status   string      `json:"status,omitempty"`
+	Location string      `json:"location,omitempty"`
+	Details  interface{} `json:"details,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The version of ECS used for field names.
+const EcsVersion = "8.11.0"
+
+// The name of the object holding fields without an ECS equivalent when Namespace is empty.
+const EcsNamespace = "senzing"
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return the texts of an "errors" value: a string for one error, a list for several.
+func ecsErrorMessage(errors interface{}) interface{} {
+	var result []string
+	elements, ok := asJsonValue(errors).([]interface{})
+	if !ok {
+		return valueAsString(errors)
+	}
+	for _, element := range elements {
+		result = append(result, consoleValue(element))
+	}
+	switch len(result) {
+	case 0:
+		return nil
+	case 1:
+		return result[0]
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Message method creates a JSON formatted message with ECS field names.
+func (messageFormat *MessageFormatEcs) Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) {
+	namespace := messageFormat.Namespace
+	if len(namespace) == 0 {
+		namespace = EcsNamespace
+	}
+
+	messageBuilder := &messageFormatEcs{
+		Timestamp:  messageTimestamp(date, time).Format(rfc3339Nano),
+		LogLevel:   level,
+		Message:    text,
+		EcsVersion: EcsVersion,
+	}
+	namespaceBuilder := &messageEcsNamespace{
+		Status: status,
+	}
+
+	if len(id) > 0 || duration != 0 {
+		messageBuilder.Event = &messageEcsEvent{
+			Code:     id,
+			Duration: duration,
+		}
+	}
+
+	if function, file, line, ok := messagelocation.ParseLocation(location); ok {
+		messageBuilder.Log = &messageEcsLog{
+			Origin: messageEcsOrigin{
+				File: messageEcsFile{
+					Name: file,
+					Line: line,
+				},
+				Function: function,
+			},
+		}
+	} else {
+		namespaceBuilder.Location = location
+	}
+
+	if !isNil(errors) {
+		messageBuilder.Error = &messageEcsError{
+			Message: ecsErrorMessage(errors),
+			Type:    status,
+		}
+	}
+
+	if !isNil(details) {
+		namespaceBuilder.Details = details
+	}
+
+	result, err := encodeJson(messageBuilder)
+	if err != nil {
+		return result, err
+	}
+	if len(namespaceBuilder.Status) == 0 && len(namespaceBuilder.Location) == 0 && namespaceBuilder.Details == nil {
+		return result, err
+	}
+
+	// The namespace is added as the last member of the object.
+
+	namespaceJson, err := encodeJson(namespaceBuilder)
+	if err != nil {
+		return result, err
+	}
+	namespaceKey, err := encodeJson(namespace)
+	return strings.TrimSuffix(result, "}") + "," + namespaceKey + ":" + namespaceJson + "}", err
+}
@@ -0,0 +1,148 @@
+/*
+The MessageFormatGelf implementation returns a message in the Graylog Extended Log Format (GELF) 1.1
+(https://go2docs.graylog.org/current/getting_in_log_data/gelf.html).
+*/
+package messageformat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The MessageFormatGelf type is for creating GELF 1.1 messages, e.g.
+
+	{"version":"1.1","host":"host-1","short_message":"Bob knows Jane","timestamp":946684800.000001,"level":6,"_1":"Bob","_2":"Jane","_message_id":"senzing-99990001"}
+
+The "text" field is short_message, or the "id" field if there is no text.
+When there are "errors", full_message is the text followed by one line per error.
+The "level" field is the syslog severity, as in MessageFormatSyslog.
+The "id", "status", "duration", and "location" fields are the additional fields
+_message_id, _status, _duration, and _location; GELF reserves _id.
+Each key of the "details" field is an additional field, e.g. _1 or _recordId.
+A detail key that matches another field is prefixed with "detail_", e.g. _detail_status.
+*/
+type MessageFormatGelf struct {
+	Host string // "host" field. If empty, the host name reported by the operating system is used.
+}
+
+// Fields in the formatted message.
+// Order is important.
+type messageFormatGelf struct {
+	Version      string      `json:"version"`
+	Host         string      `json:"host"`
+	ShortMessage string      `json:"short_message"`
+	FullMessage  string      `json:"full_message,omitempty"`
+	Timestamp    json.Number `json:"timestamp"`
+	Level        int         `json:"level"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The GELF specification version in the "version" field.
+const GelfVersion = "1.1"
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return a GELF additional field name: "_" followed by letters, digits, '_', '.', and '-'.
+func gelfFieldName(name string) string {
+	return "_" + strings.Map(func(character rune) rune {
+		switch {
+		case character >= 'a' && character <= 'z', character >= 'A' && character <= 'Z', character >= '0' && character <= '9':
+			return character
+		case character == '_', character == '.', character == '-':
+			return character
+		}
+		return '_'
+	}, name)
+}
+
+// Return a GELF additional field value. GELF allows only strings and numbers.
+func gelfFieldValue(value interface{}) interface{} {
+	switch typedValue := asJsonValue(value).(type) {
+	case json.Number:
+		return typedValue
+	case string:
+		return typedValue
+	}
+	return valueAsString(value)
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Message method creates a GELF 1.1 message.
+func (messageFormat *MessageFormatGelf) Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) {
+	host := messageFormat.Host
+	if len(host) == 0 {
+		host, _ = os.Hostname()
+	}
+	timestamp := messageTimestamp(date, time)
+
+	messageBuilder := &messageFormatGelf{
+		Version:      GelfVersion,
+		Host:         host,
+		ShortMessage: text,
+		Timestamp:    json.Number(fmt.Sprintf("%d.%06d", timestamp.Unix(), timestamp.Nanosecond()/1000)),
+		Level:        SyslogSeverity(level),
+	}
+	if len(messageBuilder.ShortMessage) == 0 {
+		messageBuilder.ShortMessage = id
+	}
+
+	if !isNil(errors) {
+		lines := []string{messageBuilder.ShortMessage}
+		if elements, ok := asJsonValue(errors).([]interface{}); ok {
+			for _, element := range elements {
+				lines = append(lines, consoleValue(element))
+			}
+		} else {
+			lines = append(lines, valueAsString(errors))
+		}
+		messageBuilder.FullMessage = strings.Join(lines, "\n")
+	}
+
+	additionalFields := map[string]interface{}{}
+	if len(id) > 0 {
+		additionalFields["_message_id"] = id
+	}
+	if len(status) > 0 {
+		additionalFields["_status"] = status
+	}
+	if duration != 0 {
+		additionalFields["_duration"] = json.Number(strconv.FormatInt(duration, 10))
+	}
+	if len(location) > 0 {
+		additionalFields["_location"] = location
+	}
+	keys, detailsMap := sortedDetails(details)
+	for _, key := range keys {
+		name := gelfFieldName(key)
+		if _, ok := additionalFields[name]; ok || name == "_id" {
+			name = gelfFieldName("detail_" + key)
+		}
+		additionalFields[name] = gelfFieldValue(detailsMap[key])
+	}
+
+	result, err := encodeJson(messageBuilder)
+	if err != nil || len(additionalFields) == 0 {
+		return result, err
+	}
+
+	// The additional fields, sorted by name, follow the standard fields.
+
+	additionalJson, err := encodeJson(additionalFields)
+	return strings.TrimSuffix(result, "}") + "," + strings.TrimPrefix(additionalJson, "{"), err
+}
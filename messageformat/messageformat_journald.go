@@ -0,0 +1,130 @@
+/*
+The MessageFormatJournald implementation returns a message in the systemd-journald native protocol
+(https://systemd.io/JOURNAL_NATIVE_PROTOCOL/).
+*/
+package messageformat
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/senzing/go-logging/messagelocation"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The MessageFormatJournald type is for creating journal entries with one field per value.
+The "text" field is MESSAGE, the "level" field determines PRIORITY, and the "id" field is MESSAGE_ID.
+CODE_FUNC, CODE_FILE, and CODE_LINE are parsed from the "location" field.
+The "status", "duration", and "errors" fields are SENZING_STATUS, SENZING_DURATION, and SENZING_ERRORS.
+Each key of the "details" field is a field named DetailPrefix followed by the key in upper case,
+e.g. DETAIL_RECORDID.
+*/
+type MessageFormatJournald struct {
+	SyslogIdentifier string // SYSLOG_IDENTIFIER field. If empty, the program name is used.
+	DetailPrefix     string // Prefix of detail field names. If empty, JournaldDetailPrefix is used.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The prefix of detail field names when DetailPrefix is empty.
+const JournaldDetailPrefix = "DETAIL_"
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Return a valid journal field name: at most 64 upper case letters, digits, and underscores,
+beginning with a letter.
+*/
+func journaldFieldName(name string) string {
+	result := []byte{}
+	for _, character := range []byte(strings.ToUpper(name)) {
+		if (character < 'A' || character > 'Z') && (character < '0' || character > '9') {
+			character = '_'
+		}
+		result = append(result, character)
+	}
+	if len(result) == 0 || result[0] < 'A' || result[0] > 'Z' {
+		result = append([]byte(JournaldDetailPrefix), result...)
+	}
+	if len(result) > 64 {
+		result = result[:64]
+	}
+	return string(result)
+}
+
+/*
+Append a field.
+Values containing a newline use the binary form: the name, a newline,
+the value length as a little-endian 64-bit integer, the value, and a newline.
+*/
+func appendJournaldField(builder *strings.Builder, name string, value string) {
+	builder.WriteString(name)
+	if strings.Contains(value, "\n") {
+		builder.WriteString("\n")
+		var length [8]byte
+		binary.LittleEndian.PutUint64(length[:], uint64(len(value)))
+		builder.Write(length[:])
+	} else {
+		builder.WriteString("=")
+	}
+	builder.WriteString(value)
+	builder.WriteString("\n")
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Message method creates a journal entry in the native protocol.
+func (messageFormat *MessageFormatJournald) Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) {
+	syslogIdentifier := messageFormat.SyslogIdentifier
+	if len(syslogIdentifier) == 0 {
+		syslogIdentifier = filepath.Base(os.Args[0])
+	}
+	detailPrefix := messageFormat.DetailPrefix
+	if len(detailPrefix) == 0 {
+		detailPrefix = JournaldDetailPrefix
+	}
+
+	var builder strings.Builder
+	message := text
+	if len(message) == 0 {
+		message = id
+	}
+	appendJournaldField(&builder, "MESSAGE", message)
+	appendJournaldField(&builder, "PRIORITY", strconv.Itoa(SyslogSeverity(level)))
+	if len(id) > 0 {
+		appendJournaldField(&builder, "MESSAGE_ID", id)
+	}
+	appendJournaldField(&builder, "SYSLOG_IDENTIFIER", syslogIdentifier)
+	if function, file, line, ok := messagelocation.ParseLocation(location); ok {
+		appendJournaldField(&builder, "CODE_FILE", file)
+		appendJournaldField(&builder, "CODE_LINE", strconv.Itoa(line))
+		appendJournaldField(&builder, "CODE_FUNC", function)
+	}
+	if len(status) > 0 {
+		appendJournaldField(&builder, "SENZING_STATUS", status)
+	}
+	if duration != 0 {
+		appendJournaldField(&builder, "SENZING_DURATION", strconv.FormatInt(duration, 10))
+	}
+	if !isNil(errors) {
+		appendJournaldField(&builder, "SENZING_ERRORS", valueAsString(errors))
+	}
+	keys, detailsMap := sortedDetails(details)
+	for _, key := range keys {
+		appendJournaldField(&builder, journaldFieldName(detailPrefix+key), valueAsString(detailsMap[key]))
+	}
+	return builder.String(), nil
+}
@@ -19,7 +19,7 @@ type MessageFormatJson struct{}
 
 // Fields in the formatted message.
 // Order is important.
-// It should be date, time, level, id, status, text, duration, location, errors, details.
+// It should be date, time, level, id, status, text, duration, location, trace_id, span_id, errors, details.
 type messageFormatJson struct {
 	Date     string      `json:"date,omitempty"`     // Date of message in UTC.
 	Time     string      `json:"time,omitempty"`     // Time of message in UTC.
@@ -29,6 +29,8 @@ type messageFormatJson struct {
 	Status   string      `json:"status,omitempty"`   // Status information.
 	Duration int64       `json:"duration,omitempty"` // Duration in nanoseconds
 	Location string      `json:"location,omitempty"` // Location in the code issuing message.
+	TraceId  string      `json:"trace_id,omitempty"` // W3C trace identifier.
+	SpanId   string      `json:"span_id,omitempty"`  // W3C span identifier.
 	Errors   interface{} `json:"errors,omitempty"`   // List of errors.
 	Details  interface{} `json:"details,omitempty"`  // All instances passed into the message.
 }
@@ -39,7 +41,15 @@ type messageFormatJson struct {
 
 // The Message method creates a JSON formatted message.
 func (messageFormat *MessageFormatJson) Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) {
-	messageBuilder := &messageFormatJson{}
+	return messageFormat.MessageWithTrace(date, time, level, location, id, status, text, duration, errors, details, "", "")
+}
+
+// The MessageWithTrace method creates a JSON formatted message with "trace_id" and "span_id" fields.
+func (messageFormat *MessageFormatJson) MessageWithTrace(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}, traceId string, spanId string) (string, error) {
+	messageBuilder := &messageFormatJson{
+		TraceId: traceId,
+		SpanId:  spanId,
+	}
 
 	if len(date) > 0 {
 		messageBuilder.Date = date
@@ -0,0 +1,157 @@
+/*
+The MessageFormatLogfmt implementation returns a message in the logfmt format (https://brandur.org/logfmt).
+*/
+package messageformat
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The MessageFormatLogfmt type is for creating messages of key=value pairs, e.g.
+
+	level=INFO id=senzing-99990001 text="Bob knows Jane" details.1=Bob details.2=Jane
+
+The fields are in the order date, time, level, id, status, text, duration, location, errors, details.
+Nested values in "errors" and "details" are flattened into dotted keys, e.g. errors.0.text.
+*/
+type MessageFormatLogfmt struct{}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return a logfmt key: spaces, '=', '"', and control characters become '_'.
+func logfmtKey(key string) string {
+	if len(key) == 0 {
+		return "_"
+	}
+	return strings.Map(func(character rune) rune {
+		if character <= ' ' || character == '=' || character == '"' || unicode.IsControl(character) {
+			return '_'
+		}
+		return character
+	}, key)
+}
+
+// Return a logfmt value, quoted if it is empty or contains spaces, '=', '"', '\', or non-printable characters.
+func logfmtValue(value string) string {
+	if len(value) == 0 {
+		return `""`
+	}
+	for _, character := range value {
+		if character <= ' ' || character == '=' || character == '"' || character == '\\' || !unicode.IsPrint(character) {
+			return strconv.Quote(value)
+		}
+	}
+	return value
+}
+
+// Append a key=value pair.
+func appendLogfmtPair(builder *strings.Builder, key string, value string) {
+	if builder.Len() > 0 {
+		builder.WriteString(" ")
+	}
+	builder.WriteString(logfmtKey(key))
+	builder.WriteString("=")
+	builder.WriteString(logfmtValue(value))
+}
+
+// Return a value as decoded JSON: maps, slices, strings, json.Number, booleans, or nil.
+func asJsonValue(value interface{}) interface{} {
+	var data []byte
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue
+	case json.RawMessage:
+		data = typedValue
+	default:
+		var err error
+		data, err = json.Marshal(value)
+		if err != nil {
+			return valueAsString(value)
+		}
+	}
+	var result interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if decoder.Decode(&result) != nil {
+		return string(data)
+	}
+	return result
+}
+
+// Append the pairs for a value, using dotted keys for the members of maps and slices.
+func appendLogfmtFlattened(builder *strings.Builder, key string, value interface{}) {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typedValue))
+		for mapKey := range typedValue {
+			keys = append(keys, mapKey)
+		}
+		sort.Strings(keys)
+		for _, mapKey := range keys {
+			appendLogfmtFlattened(builder, key+"."+mapKey, typedValue[mapKey])
+		}
+	case []interface{}:
+		for index, element := range typedValue {
+			appendLogfmtFlattened(builder, key+"."+strconv.Itoa(index), element)
+		}
+	case nil:
+		appendLogfmtPair(builder, key, "null")
+	case string:
+		appendLogfmtPair(builder, key, typedValue)
+	default:
+		appendLogfmtPair(builder, key, valueAsString(typedValue))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Message method creates a logfmt formatted message.
+func (messageFormat *MessageFormatLogfmt) Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) {
+	var builder strings.Builder
+
+	for _, field := range []struct{ key, value string }{
+		{"date", date},
+		{"time", time},
+		{"level", level},
+		{"id", id},
+		{"status", status},
+		{"text", text},
+	} {
+		if len(field.value) > 0 {
+			appendLogfmtPair(&builder, field.key, field.value)
+		}
+	}
+
+	if duration != 0 {
+		appendLogfmtPair(&builder, "duration", strconv.FormatInt(duration, 10))
+	}
+
+	if len(location) > 0 {
+		appendLogfmtPair(&builder, "location", location)
+	}
+
+	if !isNil(errors) {
+		appendLogfmtFlattened(&builder, "errors", asJsonValue(errors))
+	}
+
+	keys, detailsMap := sortedDetails(details)
+	for _, key := range keys {
+		appendLogfmtFlattened(&builder, "details."+key, asJsonValue(detailsMap[key]))
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,372 @@
+/*
+The MessageFormatMessagePack implementation returns a message in MessagePack
+(https://github.com/msgpack/msgpack/blob/master/spec.md).
+*/
+package messageformat
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The MessageFormatMessagePack type is for creating messages in MessagePack.
+The message is a map with the fields of MessageFormatSenzing, in the same order.
+Use MessagePackAsJson() to convert a message to the JSON that MessageFormatSenzing creates,
+and MessagePackStreamAsJson() for a file or stream of messages.
+*/
+type MessageFormatMessagePack struct{}
+
+// The messagePackEncoder type is the binaryEncoder for MessagePack.
+type messagePackEncoder struct{}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// The error wrapped when MessagePack data ends within a value.
+var errMessagePackTruncated = errors.New("truncated MessagePack data")
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Append the header of a string, array, or map: a fixed format if length is small enough, else 16 or 32 bits.
+func appendMessagePackHeader(buffer []byte, fixed byte, fixedLimit int, format16 byte, length int) []byte {
+	switch {
+	case length < fixedLimit:
+		return append(buffer, fixed|byte(length))
+	case length <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buffer, format16), uint16(length))
+	}
+	return binary.BigEndian.AppendUint32(append(buffer, format16+1), uint32(length))
+}
+
+// Return the big-endian unsigned integer of size bytes at offset, and the offset after it.
+func readMessagePackUint(data []byte, offset int, size int) (uint64, int, error) {
+	if len(data)-offset < size {
+		return 0, offset, fmt.Errorf("%w at offset %d", errMessagePackTruncated, offset)
+	}
+	var result uint64
+	for _, value := range data[offset : offset+size] {
+		result = result<<8 | uint64(value)
+	}
+	return result, offset + size, nil
+}
+
+// Return the offset after the value beginning at offset.
+func skipMessagePack(data []byte, offset int) (int, error) {
+	if offset >= len(data) {
+		return offset, fmt.Errorf("%w at offset %d", errMessagePackTruncated, offset)
+	}
+	format := data[offset]
+	offset++
+
+	var size uint64
+	var count uint64
+	var err error
+	switch {
+	case format <= 0x7f, format >= 0xe0, format == 0xc0, format == 0xc2, format == 0xc3:
+		return offset, nil
+	case format >= 0xcc && format <= 0xcf:
+		size = 1 << (format - 0xcc)
+	case format >= 0xd0 && format <= 0xd3:
+		size = 1 << (format - 0xd0)
+	case format == 0xca:
+		size = 4
+	case format == 0xcb:
+		size = 8
+	case format >= 0xa0 && format <= 0xbf:
+		size = uint64(format & 0x1f)
+	case format >= 0xd9 && format <= 0xdb:
+		size, offset, err = readMessagePackUint(data, offset, 1<<(format-0xd9))
+	case format >= 0xc4 && format <= 0xc6:
+		size, offset, err = readMessagePackUint(data, offset, 1<<(format-0xc4))
+	case format >= 0x90 && format <= 0x9f:
+		count = uint64(format & 0x0f)
+	case format == 0xdc, format == 0xdd:
+		count, offset, err = readMessagePackUint(data, offset, 2<<(format-0xdc))
+	case format >= 0x80 && format <= 0x8f:
+		count = 2 * uint64(format&0x0f)
+	case format == 0xde, format == 0xdf:
+		count, offset, err = readMessagePackUint(data, offset, 2<<(format-0xde))
+		count *= 2
+	default:
+		return offset, fmt.Errorf("unsupported MessagePack format 0x%02x at offset %d", format, offset-1)
+	}
+	if err != nil {
+		return offset, err
+	}
+	if uint64(len(data)-offset) < size {
+		return offset, fmt.Errorf("%w at offset %d", errMessagePackTruncated, offset)
+	}
+	offset += int(size)
+	for ; count > 0; count-- {
+		if offset, err = skipMessagePack(data, offset); err != nil {
+			return offset, err
+		}
+	}
+	return offset, nil
+}
+
+// Write the value beginning at offset as JSON and return the offset after it.
+func writeMessagePackAsJson(builder *strings.Builder, data []byte, offset int) (int, error) {
+	if offset >= len(data) {
+		return offset, fmt.Errorf("%w at offset %d", errMessagePackTruncated, offset)
+	}
+	format := data[offset]
+	offset++
+
+	var length uint64
+	var err error
+	switch {
+	case format <= 0x7f:
+		builder.WriteString(strconv.Itoa(int(format)))
+		return offset, nil
+	case format >= 0xe0:
+		builder.WriteString(strconv.Itoa(int(int8(format))))
+		return offset, nil
+	case format == 0xc0:
+		builder.WriteString("null")
+		return offset, nil
+	case format == 0xc2:
+		builder.WriteString("false")
+		return offset, nil
+	case format == 0xc3:
+		builder.WriteString("true")
+		return offset, nil
+	case format >= 0xcc && format <= 0xcf:
+		if length, offset, err = readMessagePackUint(data, offset, 1<<(format-0xcc)); err != nil {
+			return offset, err
+		}
+		builder.WriteString(strconv.FormatUint(length, 10))
+		return offset, nil
+	case format >= 0xd0 && format <= 0xd3:
+		size := 1 << (format - 0xd0)
+		if length, offset, err = readMessagePackUint(data, offset, size); err != nil {
+			return offset, err
+		}
+		value := int64(length << (64 - 8*size))
+		builder.WriteString(strconv.FormatInt(value>>(64-8*size), 10))
+		return offset, nil
+	case format == 0xca:
+		if length, offset, err = readMessagePackUint(data, offset, 4); err != nil {
+			return offset, err
+		}
+		return offset, writeJsonFloat(builder, float64(math.Float32frombits(uint32(length))))
+	case format == 0xcb:
+		if length, offset, err = readMessagePackUint(data, offset, 8); err != nil {
+			return offset, err
+		}
+		return offset, writeJsonFloat(builder, math.Float64frombits(length))
+	case format >= 0xa0 && format <= 0xbf:
+		return writeMessagePackString(builder, data, offset, uint64(format&0x1f), false)
+	case format >= 0xd9 && format <= 0xdb, format >= 0xc4 && format <= 0xc6:
+		base := byte(0xd9)
+		if format <= 0xc6 {
+			base = 0xc4
+		}
+		if length, offset, err = readMessagePackUint(data, offset, 1<<(format-base)); err != nil {
+			return offset, err
+		}
+		return writeMessagePackString(builder, data, offset, length, base == 0xc4)
+	case format >= 0x90 && format <= 0x9f:
+		length = uint64(format & 0x0f)
+	case format == 0xdc, format == 0xdd:
+		if length, offset, err = readMessagePackUint(data, offset, 2<<(format-0xdc)); err != nil {
+			return offset, err
+		}
+	case format >= 0x80 && format <= 0x8f:
+		return writeMessagePackMap(builder, data, offset, uint64(format&0x0f))
+	case format == 0xde, format == 0xdf:
+		if length, offset, err = readMessagePackUint(data, offset, 2<<(format-0xde)); err != nil {
+			return offset, err
+		}
+		return writeMessagePackMap(builder, data, offset, length)
+	default:
+		return offset, fmt.Errorf("unsupported MessagePack format 0x%02x at offset %d", format, offset-1)
+	}
+
+	// Arrays.
+
+	builder.WriteString("[")
+	for index := uint64(0); index < length; index++ {
+		if index > 0 {
+			builder.WriteString(",")
+		}
+		if offset, err = writeMessagePackAsJson(builder, data, offset); err != nil {
+			return offset, err
+		}
+	}
+	builder.WriteString("]")
+	return offset, nil
+}
+
+// Write a string, or binary data as base64, of length bytes at offset and return the offset after it.
+func writeMessagePackString(builder *strings.Builder, data []byte, offset int, length uint64, isBinary bool) (int, error) {
+	if uint64(len(data)-offset) < length {
+		return offset, fmt.Errorf("%w at offset %d", errMessagePackTruncated, offset)
+	}
+	value := data[offset : offset+int(length)]
+	offset += int(length)
+	if isBinary {
+		return offset, writeJsonString(builder, base64.StdEncoding.EncodeToString(value))
+	}
+	return offset, writeJsonString(builder, string(value))
+}
+
+// Write a map of length entries at offset as a JSON object and return the offset after it.
+func writeMessagePackMap(builder *strings.Builder, data []byte, offset int, length uint64) (int, error) {
+	var err error
+	builder.WriteString("{")
+	for index := uint64(0); index < length; index++ {
+		if index > 0 {
+			builder.WriteString(",")
+		}
+		var key strings.Builder
+		if offset, err = writeMessagePackAsJson(&key, data, offset); err != nil {
+			return offset, err
+		}
+		if strings.HasPrefix(key.String(), `"`) {
+			builder.WriteString(key.String())
+		} else if err = writeJsonString(builder, key.String()); err != nil {
+			return offset, err
+		}
+		builder.WriteString(":")
+		if offset, err = writeMessagePackAsJson(builder, data, offset); err != nil {
+			return offset, err
+		}
+	}
+	builder.WriteString("}")
+	return offset, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+func (encoder messagePackEncoder) appendNil(buffer []byte) []byte {
+	return append(buffer, 0xc0)
+}
+
+func (encoder messagePackEncoder) appendBool(buffer []byte, value bool) []byte {
+	if value {
+		return append(buffer, 0xc3)
+	}
+	return append(buffer, 0xc2)
+}
+
+func (encoder messagePackEncoder) appendInt(buffer []byte, value int64) []byte {
+	switch {
+	case value >= 0:
+		return encoder.appendUint(buffer, uint64(value))
+	case value >= -32:
+		return append(buffer, byte(value))
+	case value >= math.MinInt8:
+		return append(buffer, 0xd0, byte(value))
+	case value >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(buffer, 0xd1), uint16(value))
+	case value >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(buffer, 0xd2), uint32(value))
+	}
+	return binary.BigEndian.AppendUint64(append(buffer, 0xd3), uint64(value))
+}
+
+func (encoder messagePackEncoder) appendUint(buffer []byte, value uint64) []byte {
+	switch {
+	case value < 128:
+		return append(buffer, byte(value))
+	case value <= math.MaxUint8:
+		return append(buffer, 0xcc, byte(value))
+	case value <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buffer, 0xcd), uint16(value))
+	case value <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buffer, 0xce), uint32(value))
+	}
+	return binary.BigEndian.AppendUint64(append(buffer, 0xcf), value)
+}
+
+func (encoder messagePackEncoder) appendFloat(buffer []byte, value float64) []byte {
+	return binary.BigEndian.AppendUint64(append(buffer, 0xcb), math.Float64bits(value))
+}
+
+func (encoder messagePackEncoder) appendString(buffer []byte, value string) []byte {
+	if len(value) >= 32 && len(value) <= math.MaxUint8 {
+		buffer = append(buffer, 0xd9, byte(len(value)))
+	} else {
+		buffer = appendMessagePackHeader(buffer, 0xa0, 32, 0xda, len(value))
+	}
+	return append(buffer, value...)
+}
+
+func (encoder messagePackEncoder) appendArrayHead(buffer []byte, length int) []byte {
+	return appendMessagePackHeader(buffer, 0x90, 16, 0xdc, length)
+}
+
+func (encoder messagePackEncoder) appendMapHead(buffer []byte, length int) []byte {
+	return appendMessagePackHeader(buffer, 0x80, 16, 0xde, length)
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The MessagePackAsJson function converts a message created by MessageFormatMessagePack
+to the JSON that MessageFormatSenzing creates for the same message.
+Binary data becomes base64 strings; extension types are not supported.
+*/
+func MessagePackAsJson(message string) (string, error) {
+	var builder strings.Builder
+	offset, err := writeMessagePackAsJson(&builder, []byte(message), 0)
+	if err != nil {
+		return "", err
+	}
+	if offset != len(message) {
+		return "", fmt.Errorf("unexpected data after MessagePack message at offset %d", offset)
+	}
+	return builder.String(), nil
+}
+
+/*
+The MessagePackStreamAsJson function reads messages created by MessageFormatMessagePack from reader
+and writes each to writer as a line of the JSON that MessageFormatSenzing creates.
+The messages follow one another, as a message logger writes them.
+A newline after a message, as added by Go's log package, is skipped.
+Example:
+
+	file, _ := os.Open("messages.msgpack")
+	err := messageformat.MessagePackStreamAsJson(file, os.Stdout)
+*/
+func MessagePackStreamAsJson(reader io.Reader, writer io.Writer) error {
+	return writeStreamAsJson(reader, writer, writeMessagePackAsJson, errMessagePackTruncated)
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The MessageLength method returns the length of the MessagePack encoded message at the beginning of data.
+func (messageFormat *MessageFormatMessagePack) MessageLength(data []byte) (int, error) {
+	return skipMessagePack(data, 0)
+}
+
+// The Message method creates a MessagePack encoded message.
+func (messageFormat *MessageFormatMessagePack) Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) {
+	return messageFormat.MessageWithTrace(date, time, level, location, id, status, text, duration, errors, details, "", "")
+}
+
+// The MessageWithTrace method creates a MessagePack encoded message with "trace_id" and "span_id" fields.
+func (messageFormat *MessageFormatMessagePack) MessageWithTrace(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}, traceId string, spanId string) (string, error) {
+	return string(appendBinary(nil, messagePackEncoder{}, senzingFields(date, time, level, location, id, status, text, duration, errors, details, traceId, spanId))), nil
+}
@@ -0,0 +1,301 @@
+/*
+The MessageFormatOtlp implementation returns a message as an OpenTelemetry log record
+in the JSON encoding of OTLP (https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding).
+*/
+package messageformat
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/senzing/go-logging/messagelocation"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The MessageFormatOtlp type is for creating OpenTelemetry log records, e.g.
+
+	{"timeUnixNano":"946684800000001000","observedTimeUnixNano":"946684800000001000","severityNumber":9,"severityText":"INFO","body":{"stringValue":"Bob knows Jane"},"attributes":[{"key":"senzing.id","value":{"stringValue":"senzing-99990001"}},{"key":"senzing.details.1","value":{"stringValue":"Bob"}}]}
+
+Each message is one LogRecord; otlpsink.Writer sends them to a collector in batches.
+The fields are mapped as:
+
+  - "date" and "time" to timeUnixNano, or the current time if they are missing
+  - "level" to severityNumber, see OtlpSeverityNumber(), and severityText
+  - "text" to body. Text that is JSON is included as a structured value.
+  - "trace_id" and "span_id" to traceId and spanId
+  - "location" to the code.function, code.filepath, and code.lineno attributes
+  - "id", "status", "duration", "errors", a "location" that cannot be parsed,
+    and each key of "details" to attributes in the Namespace, e.g. senzing.id and senzing.details.1
+*/
+type MessageFormatOtlp struct {
+	Namespace string // Prefix of attributes without a semantic convention. If empty, OtlpNamespace is used.
+}
+
+// Fields in the formatted message.
+// Order is important.
+type messageFormatOtlp struct {
+	TimeUnixNano         string          `json:"timeUnixNano"`
+	ObservedTimeUnixNano string          `json:"observedTimeUnixNano"`
+	SeverityNumber       int             `json:"severityNumber,omitempty"`
+	SeverityText         string          `json:"severityText,omitempty"`
+	Body                 json.RawMessage `json:"body,omitempty"`
+	Attributes           []otlpKeyValue  `json:"attributes,omitempty"`
+	TraceId              string          `json:"traceId,omitempty"`
+	SpanId               string          `json:"spanId,omitempty"`
+}
+
+// An OTLP attribute.
+type otlpKeyValue struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+/*
+The otlpEncoder type appends a value as the JSON of an OTLP AnyValue.
+The members of a kvlistValue are each a key followed by a value.
+*/
+type otlpEncoder struct {
+	containers []otlpContainer // The arrayValue and kvlistValue values not yet complete, innermost last.
+}
+
+// An arrayValue or kvlistValue being appended.
+type otlpContainer struct {
+	isKvlist bool
+	length   int // Number of values, counting keys and values in a kvlistValue.
+	count    int // Number of values appended.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// OtlpSeverityNumberXxxx values are the OpenTelemetry severity numbers of each level.
+// PANIC is FATAL4, the most severe.
+const (
+	OtlpSeverityNumberTrace = 1
+	OtlpSeverityNumberDebug = 5
+	OtlpSeverityNumberInfo  = 9
+	OtlpSeverityNumberWarn  = 13
+	OtlpSeverityNumberError = 17
+	OtlpSeverityNumberFatal = 21
+	OtlpSeverityNumberPanic = 24
+)
+
+// The attribute prefix used when Namespace is empty.
+const OtlpNamespace = "senzing"
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+// The OtlpSeverityNumber function maps a "level" field value to an OpenTelemetry severity number.
+func OtlpSeverityNumber(level string) int {
+	switch strings.ToUpper(level) {
+	case "TRACE":
+		return OtlpSeverityNumberTrace
+	case "DEBUG":
+		return OtlpSeverityNumberDebug
+	case "WARN":
+		return OtlpSeverityNumberWarn
+	case "ERROR":
+		return OtlpSeverityNumberError
+	case "FATAL":
+		return OtlpSeverityNumberFatal
+	case "PANIC":
+		return OtlpSeverityNumberPanic
+	default:
+		return OtlpSeverityNumberInfo
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Return a value as an OTLP AnyValue.
+Integers are strings, as in the JSON encoding of protobuf, and so are doubles that are not finite.
+*/
+func otlpValue(value interface{}) json.RawMessage {
+	return appendBinary(nil, &otlpEncoder{}, value)
+}
+
+// Begin a value: add the separator before it and report whether it is the key of a kvlistValue member.
+func (encoder *otlpEncoder) begin(buffer []byte) ([]byte, bool) {
+	if len(encoder.containers) == 0 {
+		return buffer, false
+	}
+	top := encoder.containers[len(encoder.containers)-1]
+	if top.isKvlist && top.count%2 == 1 {
+		return buffer, false
+	}
+	if top.count > 0 {
+		buffer = append(buffer, ',')
+	}
+	return buffer, top.isKvlist
+}
+
+// End a value, closing the containers that it completes.
+func (encoder *otlpEncoder) end(buffer []byte) []byte {
+	for len(encoder.containers) > 0 {
+		top := &encoder.containers[len(encoder.containers)-1]
+		if top.isKvlist && top.count%2 == 1 {
+			buffer = append(buffer, '}')
+		}
+		top.count++
+		if top.count < top.length {
+			return buffer
+		}
+		encoder.containers = encoder.containers[:len(encoder.containers)-1]
+		buffer = append(buffer, "]}}"...)
+	}
+	return buffer
+}
+
+// Append a scalar AnyValue.
+func (encoder *otlpEncoder) appendScalar(buffer []byte, name string, value string) []byte {
+	buffer, _ = encoder.begin(buffer)
+	buffer = append(buffer, `{"`+name+`":`+value+`}`...)
+	return encoder.end(buffer)
+}
+
+// Append an arrayValue or kvlistValue, with length values to follow.
+func (encoder *otlpEncoder) appendContainer(buffer []byte, name string, length int, isKvlist bool) []byte {
+	buffer, _ = encoder.begin(buffer)
+	buffer = append(buffer, `{"`+name+`":{"values":[`...)
+	if length == 0 {
+		return encoder.end(append(buffer, "]}}"...))
+	}
+	encoder.containers = append(encoder.containers, otlpContainer{isKvlist: isKvlist, length: length})
+	return buffer
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+func (encoder *otlpEncoder) appendNil(buffer []byte) []byte {
+	buffer, _ = encoder.begin(buffer)
+	return encoder.end(append(buffer, "{}"...))
+}
+
+func (encoder *otlpEncoder) appendBool(buffer []byte, value bool) []byte {
+	return encoder.appendScalar(buffer, "boolValue", strconv.FormatBool(value))
+}
+
+func (encoder *otlpEncoder) appendInt(buffer []byte, value int64) []byte {
+	return encoder.appendScalar(buffer, "intValue", strconv.Quote(strconv.FormatInt(value, 10)))
+}
+
+func (encoder *otlpEncoder) appendUint(buffer []byte, value uint64) []byte {
+	if value > math.MaxInt64 {
+		return encoder.appendScalar(buffer, "stringValue", strconv.Quote(strconv.FormatUint(value, 10)))
+	}
+	return encoder.appendScalar(buffer, "intValue", strconv.Quote(strconv.FormatUint(value, 10)))
+}
+
+func (encoder *otlpEncoder) appendFloat(buffer []byte, value float64) []byte {
+	switch {
+	case math.IsNaN(value):
+		return encoder.appendScalar(buffer, "doubleValue", `"NaN"`)
+	case math.IsInf(value, 1):
+		return encoder.appendScalar(buffer, "doubleValue", `"Infinity"`)
+	case math.IsInf(value, -1):
+		return encoder.appendScalar(buffer, "doubleValue", `"-Infinity"`)
+	}
+	result, _ := json.Marshal(value)
+	return encoder.appendScalar(buffer, "doubleValue", string(result))
+}
+
+func (encoder *otlpEncoder) appendString(buffer []byte, value string) []byte {
+	quoted, _ := encodeJson(value)
+	buffer, isKey := encoder.begin(buffer)
+	if isKey {
+		buffer = append(buffer, `{"key":`+quoted+`,"value":`...)
+		return encoder.end(buffer)
+	}
+	buffer = append(buffer, `{"stringValue":`+quoted+`}`...)
+	return encoder.end(buffer)
+}
+
+func (encoder *otlpEncoder) appendArrayHead(buffer []byte, length int) []byte {
+	return encoder.appendContainer(buffer, "arrayValue", length, false)
+}
+
+func (encoder *otlpEncoder) appendMapHead(buffer []byte, length int) []byte {
+	return encoder.appendContainer(buffer, "kvlistValue", length*2, true)
+}
+
+// The Message method creates an OTLP log record.
+func (messageFormat *MessageFormatOtlp) Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) {
+	return messageFormat.MessageWithTrace(date, time, level, location, id, status, text, duration, errors, details, "", "")
+}
+
+// The MessageWithTrace method creates an OTLP log record with traceId and spanId.
+func (messageFormat *MessageFormatOtlp) MessageWithTrace(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}, traceId string, spanId string) (string, error) {
+	namespace := messageFormat.Namespace
+	if len(namespace) == 0 {
+		namespace = OtlpNamespace
+	}
+
+	messageBuilder := &messageFormatOtlp{
+		TimeUnixNano:         strconv.FormatInt(messageTimestamp(date, time).UnixNano(), 10),
+		ObservedTimeUnixNano: strconv.FormatInt(timeNow().UnixNano(), 10),
+		SeverityText:         level,
+		TraceId:              traceId,
+		SpanId:               spanId,
+	}
+	if len(level) > 0 {
+		messageBuilder.SeverityNumber = OtlpSeverityNumber(level)
+	}
+	if len(text) > 0 {
+		if isJson(text) {
+			messageBuilder.Body = otlpValue(jsonAsInterface(text))
+		} else {
+			messageBuilder.Body = otlpValue(text)
+		}
+	}
+
+	attribute := func(key string, value interface{}) {
+		messageBuilder.Attributes = append(messageBuilder.Attributes, otlpKeyValue{Key: key, Value: otlpValue(value)})
+	}
+	if len(id) > 0 {
+		attribute(namespace+".id", id)
+	}
+	if len(status) > 0 {
+		attribute(namespace+".status", status)
+	}
+	if duration != 0 {
+		attribute(namespace+".duration", duration)
+	}
+	if function, file, line, ok := messagelocation.ParseLocation(location); ok {
+		attribute("code.function", function)
+		attribute("code.filepath", file)
+		attribute("code.lineno", line)
+	} else if len(location) > 0 {
+		attribute(namespace+".location", location)
+	}
+	if !isNil(errors) {
+		if elements, ok := asJsonValue(errors).([]interface{}); ok {
+			texts := make([]interface{}, 0, len(elements))
+			for _, element := range elements {
+				texts = append(texts, consoleValue(element))
+			}
+			attribute(namespace+".errors", texts)
+		} else {
+			attribute(namespace+".errors", []interface{}{valueAsString(errors)})
+		}
+	}
+	keys, detailsMap := sortedDetails(details)
+	for _, key := range keys {
+		attribute(namespace+".details."+key, detailsMap[key])
+	}
+
+	return encodeJson(messageBuilder)
+}
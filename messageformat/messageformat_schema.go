@@ -0,0 +1,185 @@
+/*
+The JSON Schema of the messages created by MessageFormatSenzing and MessageFormatJson.
+*/
+package messageformat
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+/*
+The version of the Senzing message format, also the "version" of its schema.
+The major version changes when a field is removed or its type changes;
+the minor version changes when a field is added.
+*/
+const SenzingFormatVersion = "1.1.0"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+//go:embed schema/senzing-message.schema.json
+var senzingSchema []byte
+
+var (
+	senzingSchemaOnce   sync.Once
+	senzingSchemaParsed map[string]interface{}
+	senzingSchemaErr    error
+)
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return the JSON type of a value decoded with UseNumber().
+func jsonType(value interface{}) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if strings.ContainsAny(string(typedValue), ".eE") {
+			return "number"
+		}
+		return "integer"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// Return true if a value of actual JSON type is allowed by the "type" keyword of a schema.
+func isSchemaType(schemaType interface{}, actual string) bool {
+	switch typedValue := schemaType.(type) {
+	case nil:
+		return true
+	case string:
+		return typedValue == actual || (typedValue == "number" && actual == "integer")
+	case []interface{}:
+		for _, element := range typedValue {
+			if isSchemaType(element, actual) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+Validate a value against a schema, returning an error for each violation.
+The keywords type, enum, pattern, required, properties, additionalProperties, and items are checked;
+others, such as description, are annotations.
+*/
+func validateSchema(value interface{}, schema map[string]interface{}, path string) []error {
+	var result []error
+	actualType := jsonType(value)
+	if !isSchemaType(schema["type"], actualType) {
+		return append(result, fmt.Errorf("%s: %s is not of type %v", path, actualType, schema["type"]))
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, element := range enum {
+			found = found || fmt.Sprint(element) == fmt.Sprint(value)
+		}
+		if !found {
+			result = append(result, fmt.Errorf("%s: %v is not one of %v", path, value, enum))
+		}
+	}
+	switch typedValue := value.(type) {
+	case string:
+		if pattern, ok := schema["pattern"].(string); ok {
+			matched, err := regexp.MatchString(pattern, typedValue)
+			switch {
+			case err != nil:
+				result = append(result, fmt.Errorf("%s: %w", path, err))
+			case !matched:
+				result = append(result, fmt.Errorf("%s: %q does not match %q", path, typedValue, pattern))
+			}
+		}
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, ok := typedValue[fmt.Sprint(name)]; !ok {
+					result = append(result, fmt.Errorf("%s: missing required %q", path, name))
+				}
+			}
+		}
+		keys, _ := sortedDetails(typedValue)
+		for _, key := range keys {
+			if propertySchema, ok := properties[key].(map[string]interface{}); ok {
+				result = append(result, validateSchema(typedValue[key], propertySchema, path+"/"+key)...)
+				continue
+			}
+			switch additionalProperties := schema["additionalProperties"].(type) {
+			case bool:
+				if !additionalProperties {
+					result = append(result, fmt.Errorf("%s: unexpected %q", path, key))
+				}
+			case map[string]interface{}:
+				result = append(result, validateSchema(typedValue[key], additionalProperties, path+"/"+key)...)
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for index, element := range typedValue {
+				result = append(result, validateSchema(element, items, fmt.Sprintf("%s/%d", path, index))...)
+			}
+		}
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+// The SenzingSchema function returns the JSON Schema of the messages created by MessageFormatSenzing and MessageFormatJson.
+func SenzingSchema() []byte {
+	return append([]byte{}, senzingSchema...)
+}
+
+/*
+The ValidateSenzing function returns an error if message, created by MessageFormatSenzing or MessageFormatJson,
+does not match SenzingSchema().
+The message is the JSON only; a prefix added by log flags, such as log.LstdFlags, must be removed first.
+Each violation is reported with the path of the value, e.g. "message/errors/0: unexpected \"code\"".
+*/
+func ValidateSenzing(message string) error {
+	senzingSchemaOnce.Do(func() {
+		senzingSchemaErr = json.Unmarshal(senzingSchema, &senzingSchemaParsed)
+	})
+	if senzingSchemaErr != nil {
+		return senzingSchemaErr
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(message)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+	offset := decoder.InputOffset()
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after message at offset %d", offset)
+	}
+
+	return errors.Join(validateSchema(value, senzingSchemaParsed, "message")...)
+}
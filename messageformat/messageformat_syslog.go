@@ -0,0 +1,216 @@
+/*
+The MessageFormatSyslog implementation returns a message in the syslog format of RFC 5424
+(https://www.rfc-editor.org/rfc/rfc5424).
+*/
+package messageformat
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The MessageFormatSyslog type is for creating RFC 5424 syslog messages.
+The "level" field determines the severity, the "date" and "time" fields are the TIMESTAMP,
+or the current time if they are missing, the "id" field is the MSGID,
+and the "text" field is the MSG.
+The "status", "duration", "location", and "errors" fields are in the "senzing" structured data element;
+the "details" field is in the "details" structured data element.
+*/
+type MessageFormatSyslog struct {
+	Facility         int    // Facility, see RFC 5424 section 6.2.1. If 0, SyslogFacilityUser is used.
+	Hostname         string // HOSTNAME field. If empty, os.Hostname() is used.
+	AppName          string // APP-NAME field. If empty, the program name is used.
+	ProcId           string // PROCID field. If empty, the process id is used.
+	EnterpriseNumber int    // Private enterprise number in structured data ids. If 0, SyslogEnterpriseNumber is used.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// SyslogFacilityXxxx values are the facilities of RFC 5424 section 6.2.1 meant for applications.
+const (
+	SyslogFacilityUser   = 1
+	SyslogFacilityDaemon = 3
+	SyslogFacilityLocal0 = 16
+	SyslogFacilityLocal1 = 17
+	SyslogFacilityLocal2 = 18
+	SyslogFacilityLocal3 = 19
+	SyslogFacilityLocal4 = 20
+	SyslogFacilityLocal5 = 21
+	SyslogFacilityLocal6 = 22
+	SyslogFacilityLocal7 = 23
+)
+
+// SyslogSeverityXxxx values are the severities of RFC 5424 section 6.2.1.
+const (
+	SyslogSeverityEmergency = 0
+	SyslogSeverityAlert     = 1
+	SyslogSeverityCritical  = 2
+	SyslogSeverityError     = 3
+	SyslogSeverityWarning   = 4
+	SyslogSeverityNotice    = 5
+	SyslogSeverityInfo      = 6
+	SyslogSeverityDebug     = 7
+)
+
+// The private enterprise number used when EnterpriseNumber is 0.
+// 32473 is reserved for documentation by RFC 5612.
+const SyslogEnterpriseNumber = 32473
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+// The SyslogSeverity function maps a "level" field value to a syslog severity.
+func SyslogSeverity(level string) int {
+	switch strings.ToUpper(level) {
+	case "TRACE", "DEBUG":
+		return SyslogSeverityDebug
+	case "WARN":
+		return SyslogSeverityWarning
+	case "ERROR":
+		return SyslogSeverityError
+	case "FATAL":
+		return SyslogSeverityCritical
+	case "PANIC":
+		return SyslogSeverityEmergency
+	default:
+		return SyslogSeverityInfo
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return a header field: printable US-ASCII without spaces, at most maxLength characters, or "-" if empty.
+func syslogHeaderField(value string, maxLength int) string {
+	result := []byte{}
+	for index := 0; index < len(value) && len(result) < maxLength; index++ {
+		character := value[index]
+		if character < 33 || character > 126 {
+			character = '_'
+		}
+		result = append(result, character)
+	}
+	if len(result) == 0 {
+		return "-"
+	}
+	return string(result)
+}
+
+// Return a structured data parameter name: at most 32 printable US-ASCII characters other than '=', ' ', ']', and '"'.
+func syslogParameterName(name string) string {
+	result := []byte{}
+	for index := 0; index < len(name) && len(result) < 32; index++ {
+		character := name[index]
+		if character < 33 || character > 126 || character == '=' || character == ']' || character == '"' {
+			character = '_'
+		}
+		result = append(result, character)
+	}
+	if len(result) == 0 {
+		return "_"
+	}
+	return string(result)
+}
+
+// Escape '"', '\', and ']' in a structured data parameter value.
+func syslogParameterValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
+
+// Append a structured data parameter.
+func appendSyslogParameter(builder *strings.Builder, name string, value string) {
+	builder.WriteString(" ")
+	builder.WriteString(syslogParameterName(name))
+	builder.WriteString(`="`)
+	builder.WriteString(syslogParameterValue(value))
+	builder.WriteString(`"`)
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Message method creates an RFC 5424 syslog message.
+func (messageFormat *MessageFormatSyslog) Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) {
+	facility := messageFormat.Facility
+	if facility == 0 {
+		facility = SyslogFacilityUser
+	}
+	enterpriseNumber := messageFormat.EnterpriseNumber
+	if enterpriseNumber == 0 {
+		enterpriseNumber = SyslogEnterpriseNumber
+	}
+	hostname := messageFormat.Hostname
+	if len(hostname) == 0 {
+		hostname, _ = os.Hostname()
+	}
+	appName := messageFormat.AppName
+	if len(appName) == 0 {
+		appName = filepath.Base(os.Args[0])
+	}
+	procId := messageFormat.ProcId
+	if len(procId) == 0 {
+		procId = strconv.Itoa(os.Getpid())
+	}
+
+	// Header.
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "<%d>1 %s %s %s %s %s ",
+		facility*8+SyslogSeverity(level),
+		messageTimestamp(date, time).UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogHeaderField(hostname, 255),
+		syslogHeaderField(appName, 48),
+		syslogHeaderField(procId, 128),
+		syslogHeaderField(id, 32))
+
+	// Structured data.
+
+	var senzingElement strings.Builder
+	if len(status) > 0 {
+		appendSyslogParameter(&senzingElement, "status", status)
+	}
+	if duration != 0 {
+		appendSyslogParameter(&senzingElement, "duration", strconv.FormatInt(duration, 10))
+	}
+	if len(location) > 0 {
+		appendSyslogParameter(&senzingElement, "location", location)
+	}
+	if !isNil(errors) {
+		appendSyslogParameter(&senzingElement, "errors", valueAsString(errors))
+	}
+	var detailsElement strings.Builder
+	keys, detailsMap := sortedDetails(details)
+	for _, key := range keys {
+		appendSyslogParameter(&detailsElement, key, valueAsString(detailsMap[key]))
+	}
+	if senzingElement.Len() == 0 && detailsElement.Len() == 0 {
+		builder.WriteString("-")
+	}
+	if senzingElement.Len() > 0 {
+		fmt.Fprintf(&builder, "[senzing@%d%s]", enterpriseNumber, senzingElement.String())
+	}
+	if detailsElement.Len() > 0 {
+		fmt.Fprintf(&builder, "[details@%d%s]", enterpriseNumber, detailsElement.String())
+	}
+
+	// Message.
+
+	if len(text) > 0 {
+		builder.WriteString(" ")
+		builder.WriteString(text)
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,149 @@
+/*
+The MessageFormatTemplated implementation returns a message laid out by a text/template
+(https://pkg.go.dev/text/template).
+*/
+package messageformat
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode/utf8"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The MessageFormatTemplated type is for creating messages from a template, e.g.
+
+	{{.Date}} {{.Time}} {{.Level | pad 5}} {{.Id}}: {{.Text | truncate 80}} {{json .Details}}
+
+The template can use the fields .Date, .Time, .Level, .Location, .Id, .Status, .Text, .Duration, .Errors, and .Details,
+and these functions:
+
+  - json: the value as JSON, e.g. {{json .Details}}
+  - pad: the value padded with spaces to a width; a negative width pads on the left, e.g. {{.Level | pad 5}}
+  - truncate: the value cut to a number of characters, e.g. {{.Text | truncate 80}}
+  - color: the value in an ANSI color, e.g. {{.Id | color "cyan"}}; see AnsiColors for the names
+  - levelColor: the value in the color of a level, e.g. {{.Level | levelColor .Level}}
+
+The template is parsed on first use; a template that does not parse is reported by Message().
+*/
+type MessageFormatTemplated struct {
+	Template      string // The text/template for a message.
+	NoColor       bool   // If true, color and levelColor do not add color.
+	mutex         sync.Mutex
+	parsed        *template.Template
+	source        string // The Template value that was parsed.
+	sourceNoColor bool   // The NoColor value when the template was parsed.
+}
+
+// The fields available to a MessageFormatTemplated template.
+type messageFormatTemplatedFields struct {
+	Date     string
+	Time     string
+	Level    string
+	Location string
+	Id       string
+	Status   string
+	Text     string
+	Duration int64
+	Errors   interface{}
+	Details  interface{}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return the template functions, with or without color.
+func templateFuncs(noColor bool) template.FuncMap {
+	color := func(name string, value interface{}) string {
+		return colorize(name, fmt.Sprint(value))
+	}
+	levelColor := func(level string, value interface{}) string {
+		return colorize(LevelColors[strings.ToUpper(level)], fmt.Sprint(value))
+	}
+	if noColor {
+		color = func(name string, value interface{}) string {
+			return fmt.Sprint(value)
+		}
+		levelColor = color
+	}
+	return template.FuncMap{
+		"color":      color,
+		"json":       encodeJson,
+		"levelColor": levelColor,
+		"pad":        templatePad,
+		"truncate":   templateTruncate,
+	}
+}
+
+// Return value padded with spaces to width characters; a negative width pads on the left.
+func templatePad(width int, value interface{}) string {
+	return fmt.Sprintf("%*s", -width, fmt.Sprint(value))
+}
+
+// Return value cut to at most length characters.
+func templateTruncate(length int, value interface{}) string {
+	text := fmt.Sprint(value)
+	if length < 0 || utf8.RuneCountInString(text) <= length {
+		return text
+	}
+	return string([]rune(text)[:length])
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Return the parsed template, parsing it if Template or NoColor has changed.
+func (messageFormat *MessageFormatTemplated) parsedTemplate() (*template.Template, error) {
+	messageFormat.mutex.Lock()
+	defer messageFormat.mutex.Unlock()
+	if messageFormat.parsed != nil && messageFormat.source == messageFormat.Template && messageFormat.sourceNoColor == messageFormat.NoColor {
+		return messageFormat.parsed, nil
+	}
+	parsed, err := template.New("message").Funcs(templateFuncs(messageFormat.NoColor)).Parse(messageFormat.Template)
+	if err != nil {
+		return nil, err
+	}
+	messageFormat.parsed = parsed
+	messageFormat.source = messageFormat.Template
+	messageFormat.sourceNoColor = messageFormat.NoColor
+	return parsed, nil
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Message method creates a message by executing the template.
+func (messageFormat *MessageFormatTemplated) Message(date string, time string, level string, location string, id string, status string, text string, duration int64, errors interface{}, details interface{}) (string, error) {
+	parsed, err := messageFormat.parsedTemplate()
+	if err != nil {
+		return "", err
+	}
+	fields := &messageFormatTemplatedFields{
+		Date:     date,
+		Time:     time,
+		Level:    level,
+		Location: location,
+		Id:       id,
+		Status:   status,
+		Text:     text,
+		Duration: duration,
+	}
+	if !isNil(errors) {
+		fields.Errors = errors
+	}
+	if !isNil(details) {
+		fields.Details = details
+	}
+	var result strings.Builder
+	err = parsed.Execute(&result, fields)
+	return result.String(), err
+}
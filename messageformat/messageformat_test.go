@@ -1,7 +1,20 @@
 package messageformat
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -21,6 +34,7 @@ var testCases = []struct {
 	expectedDefault string
 	expectedJson    string
 	expectedSenzing string
+	expectedLogfmt  string
 }{
 	{
 		name:            "messageformat-01",
@@ -31,6 +45,7 @@ var testCases = []struct {
 		expectedDefault: `id-1: (status-1) text-1 map[1:123 2:bob]`,
 		expectedJson:    `{"id":"id-1","text":"text-1","status":"status-1","details":{"1":123,"2":"bob"}}`,
 		expectedSenzing: `{"id":"id-1","text":"text-1","status":"status-1","details":{"1":123,"2":"bob"}}`,
+		expectedLogfmt:  `id=id-1 status=status-1 text=text-1 details.1=123 details.2=bob`,
 	},
 	{
 		name:            "messageformat-02-no_id",
@@ -40,6 +55,7 @@ var testCases = []struct {
 		expectedDefault: `(status-2) text-2 map[1:123 2:bob]`,
 		expectedJson:    `{"text":"text-2","status":"status-2","details":{"1":123,"2":"bob"}}`,
 		expectedSenzing: `{"text":"text-2","status":"status-2","details":{"1":123,"2":"bob"}}`,
+		expectedLogfmt:  `status=status-2 text=text-2 details.1=123 details.2=bob`,
 	},
 	{
 		name:            "messageformat-03-no_status",
@@ -49,6 +65,7 @@ var testCases = []struct {
 		expectedDefault: `id-3: text-3 map[1:123 2:bob]`,
 		expectedJson:    `{"id":"id-3","text":"text-3","details":{"1":123,"2":"bob"}}`,
 		expectedSenzing: `{"id":"id-3","text":"text-3","details":{"1":123,"2":"bob"}}`,
+		expectedLogfmt:  `id=id-3 text=text-3 details.1=123 details.2=bob`,
 	},
 	{
 		name:            "messageformat-04-no_text",
@@ -58,6 +75,7 @@ var testCases = []struct {
 		expectedDefault: `id-4: (status-4) map[1:123 2:bob]`,
 		expectedJson:    `{"id":"id-4","status":"status-4","details":{"1":123,"2":"bob"}}`,
 		expectedSenzing: `{"id":"id-4","status":"status-4","details":{"1":123,"2":"bob"}}`,
+		expectedLogfmt:  `id=id-4 status=status-4 details.1=123 details.2=bob`,
 	},
 	{
 		name:            "messageformat-05-no_details",
@@ -67,6 +85,7 @@ var testCases = []struct {
 		expectedDefault: `id-5: (status-5) text-5`,
 		expectedJson:    `{"id":"id-5","text":"text-5","status":"status-5"}`,
 		expectedSenzing: `{"id":"id-5","text":"text-5","status":"status-5"}`,
+		expectedLogfmt:  `id=id-5 status=status-5 text=text-5`,
 	},
 	{
 		name:            "messageformat-06",
@@ -87,6 +106,7 @@ var testCases = []struct {
 		expectedDefault: `level-10 id-10: (status-10) text-10`,
 		expectedJson:    `{"date":"date-10","time":"time-10","level":"level-10","id":"id-10","text":"text-10","status":"status-10","location":"location-10"}`,
 		expectedSenzing: `{"date":"date-10","time":"time-10","level":"level-10","id":"id-10","text":"text-10","status":"status-10","location":"location-10"}`,
+		expectedLogfmt:  `date=date-10 time=time-10 level=level-10 id=id-10 status=status-10 text=text-10 location=location-10`,
 	},
 	{
 		name:            "messageformat-11-Add_duration",
@@ -101,6 +121,7 @@ var testCases = []struct {
 		expectedDefault: `level-11 id-11: (status-11) text-11`,
 		expectedJson:    `{"date":"date-11","time":"time-11","level":"level-11","id":"id-11","text":"text-11","status":"status-11","duration":11,"location":"location-11"}`,
 		expectedSenzing: `{"date":"date-11","time":"time-11","level":"level-11","id":"id-11","text":"text-11","status":"status-11","duration":11,"location":"location-11"}`,
+		expectedLogfmt:  `date=date-11 time=time-11 level=level-11 id=id-11 status=status-11 text=text-11 duration=11 location=location-11`,
 	},
 }
 
@@ -164,3 +185,594 @@ func TestMessageFormatSenzing(test *testing.T) {
 		}
 	}
 }
+
+// ----------------------------------------------------------------------------
+// Test interface functions for MessageFormatLogfmt
+// ----------------------------------------------------------------------------
+
+func TestMessageFormatLogfmt(test *testing.T) {
+	for _, testCase := range testCases {
+		if len(testCase.expectedLogfmt) > 0 {
+			test.Run(testCase.name+"-Logfmt", func(test *testing.T) {
+				testObject := &MessageFormatLogfmt{}
+				actual, err := testObject.Message(testCase.date, testCase.time, testCase.level, testCase.location, testCase.id, testCase.status, testCase.text, testCase.duration, testCase.errors, testCase.details)
+				testError(test, testObject, err)
+				assert.Equal(test, testCase.expectedLogfmt, actual, testCase.name)
+			})
+		}
+	}
+}
+
+func TestMessageFormatLogfmtQuotingAndFlattening(test *testing.T) {
+	testObject := &MessageFormatLogfmt{}
+	errors := []interface{}{map[string]interface{}{"text": "error #1"}, map[string]interface{}{"text": json.RawMessage(`{"code": 9995}`)}}
+	details := map[string]interface{}{
+		"empty":  "",
+		"json":   json.RawMessage(`{"A": {"B": 1.5}, "C": [true, null]}`),
+		"my key": `say "hi"=\`,
+	}
+	actual, err := testObject.Message("", "", "ERROR", "In main() at main.go:12", "id-1", "", "Bob knows Jane", 0, errors, details)
+	testError(test, testObject, err)
+	expected := `level=ERROR id=id-1 text="Bob knows Jane" location="In main() at main.go:12" ` +
+		`errors.0.text="error #1" errors.1.text.code=9995 ` +
+		`details.empty="" details.json.A.B=1.5 details.json.C.0=true details.json.C.1=null details.my_key="say \"hi\"=\\"`
+	assert.Equal(test, expected, actual)
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions for MessageFormatTemplated
+// ----------------------------------------------------------------------------
+
+func TestMessageFormatTemplated(test *testing.T) {
+	testObject := &MessageFormatTemplated{
+		Template: `{{.Level | pad 5}}|{{.Id | pad -6}}|{{.Text | truncate 8}}|{{.Duration}}|{{json .Details}}{{with .Errors}}|{{json .}}{{end}}`,
+	}
+	actual, err := testObject.Message("", "", "INFO", "", "id-1", "", "Bob knows Jane", 11, nil, map[string]interface{}{"1": "Bob", "2": "<Jane>"})
+	testError(test, testObject, err)
+	assert.Equal(test, `INFO |  id-1|Bob know|11|{"1":"Bob","2":"<Jane>"}`, actual)
+
+	actual, err = testObject.Message("", "", "WARN", "", "id-2", "", "", 0, []interface{}{map[string]string{"text": "error #1"}}, nil)
+	testError(test, testObject, err)
+	assert.Equal(test, `WARN |  id-2||0|null|[{"text":"error #1"}]`, actual)
+}
+
+func TestMessageFormatTemplatedColor(test *testing.T) {
+	testObject := &MessageFormatTemplated{
+		Template: `{{.Level | levelColor .Level}} {{.Id | color "cyan"}} {{.Text | color "unknown"}}`,
+	}
+	actual, err := testObject.Message("", "", "ERROR", "", "id-1", "", "text-1", 0, nil, nil)
+	testError(test, testObject, err)
+	assert.Equal(test, "\x1b[31mERROR\x1b[0m \x1b[36mid-1\x1b[0m text-1", actual)
+
+	testObject.NoColor = true
+	actual, err = testObject.Message("", "", "ERROR", "", "id-1", "", "text-1", 0, nil, nil)
+	testError(test, testObject, err)
+	assert.Equal(test, "ERROR id-1 text-1", actual)
+}
+
+func TestMessageFormatTemplatedBadTemplate(test *testing.T) {
+	testObject := &MessageFormatTemplated{Template: `{{.Level`}
+	_, err := testObject.Message("", "", "INFO", "", "", "", "", 0, nil, nil)
+	assert.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions for MessageFormatConsole
+// ----------------------------------------------------------------------------
+
+func TestMessageFormatConsole(test *testing.T) {
+	testObject := &MessageFormatConsole{Output: &bytes.Buffer{}}
+	errors := []interface{}{map[string]interface{}{"text": "error #1"}}
+	details := map[string]interface{}{"2": "Jane", "1": "Bob", "json": json.RawMessage(`{"A": 1}`)}
+	actual, err := testObject.Message("2000-01-01", "00:00:00", "INFO", "In main() at main.go:12", "id-1", "status-1", "Bob knows Jane", 11, errors, details)
+	testError(test, testObject, err)
+	expected := "2000-01-01 00:00:00 INFO  id-1: (status-1) Bob knows Jane [11ns] In main() at main.go:12" +
+		"\n    details:\n        1: Bob\n        2: Jane\n        json: {\"A\":1}" +
+		"\n    errors:\n        - error #1"
+	assert.Equal(test, expected, actual)
+}
+
+func TestMessageFormatConsoleColor(test *testing.T) {
+	testObject := &MessageFormatConsole{}
+	testObject.once.Do(func() {})
+	testObject.isColor = true
+	actual, err := testObject.Message("", "", "WARN", "In main() at main.go:12", "id-1", "", "text-1", 0, nil, map[string]interface{}{"1": "Bob"})
+	testError(test, testObject, err)
+	assert.Equal(test, "\x1b[33mWARN \x1b[0m id-1: text-1 \x1b[2mIn main() at main.go:12\x1b[0m\n    details:\n        \x1b[36m1\x1b[0m: Bob", actual)
+
+	testObject.NoColor = true
+	actual, err = testObject.Message("", "", "WARN", "", "id-1", "", "text-1", 0, nil, nil)
+	testError(test, testObject, err)
+	assert.Equal(test, "WARN  id-1: text-1", actual)
+}
+
+func TestIsColorTerminal(test *testing.T) {
+	assert.False(test, IsColorTerminal(&bytes.Buffer{}))
+	test.Setenv("NO_COLOR", "1")
+	assert.False(test, IsColorTerminal(os.Stderr))
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions for the Senzing schema
+// ----------------------------------------------------------------------------
+
+func TestSenzingSchema(test *testing.T) {
+	var schema map[string]interface{}
+	testError(test, nil, json.Unmarshal(SenzingSchema(), &schema))
+	assert.Equal(test, SenzingFormatVersion, schema["version"])
+	assert.Equal(test, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+
+	// Every field of the Senzing format is described by the schema.
+
+	properties := schema["properties"].(map[string]interface{})
+	fieldType := reflect.TypeOf(messageFormatSenzing{})
+	assert.Len(test, properties, fieldType.NumField())
+	for index := 0; index < fieldType.NumField(); index++ {
+		name := strings.Split(fieldType.Field(index).Tag.Get("json"), ",")[0]
+		assert.Contains(test, properties, name)
+	}
+
+	// The result is a copy.
+
+	SenzingSchema()[0] = 'x'
+	assert.True(test, json.Valid(SenzingSchema()))
+}
+
+func TestValidateSenzing(test *testing.T) {
+	for _, testCase := range testCases {
+		if len(testCase.expectedSenzing) > 0 {
+			test.Run(testCase.name, func(test *testing.T) {
+				assert.NoError(test, ValidateSenzing(testCase.expectedSenzing))
+				assert.NoError(test, ValidateSenzing(testCase.expectedJson))
+			})
+		}
+	}
+	testErrors := []interface{}{errors.New("error #1")}
+	details := map[string]interface{}{"1": "Bob", "json": json.RawMessage(`{"A": [1, 2.5]}`)}
+	for _, messageFormat := range []MessageFormatInterface{&MessageFormatSenzing{}, &MessageFormatJson{}} {
+		message, err := messageFormat.Message("2000-01-01", "00:00:00.000000000", "ERROR", "In main() at main.go:12", "id-1", "status-1", `{"text": 1}`, 11, testErrors, details)
+		testError(test, messageFormat, err)
+		assert.NoError(test, ValidateSenzing(message+"\n"), message)
+	}
+}
+
+func TestValidateSenzingViolations(test *testing.T) {
+	for _, testCase := range []struct {
+		message  string
+		expected string
+	}{
+		{`[]`, `message: array is not of type object`},
+		{`{"level":3}`, `message/level: integer is not of type string`},
+		{`{"duration":1.5}`, `message/duration: number is not of type integer`},
+		{`{"extra":1,"level":"INFO"}`, `message: unexpected "extra"`},
+		{`{"errors":[{"text":"a"},{"code":1}]}`, `message/errors/1: unexpected "code"`},
+		{`{"details":[1],"id":1}`, "message/details: array is not of type object\nmessage/id: integer is not of type string"},
+		{`{"span_id":"00F067AA0BA902B7"}`, `message/span_id: "00F067AA0BA902B7" does not match "^[0-9a-f]{16}$"`},
+		{`{} {}`, `unexpected data after message at offset 2`},
+	} {
+		err := ValidateSenzing(testCase.message)
+		if assert.Error(test, err, testCase.message) {
+			assert.Equal(test, testCase.expected, err.Error(), testCase.message)
+		}
+	}
+	assert.Error(test, ValidateSenzing("2000/01/01 00:00:00 {}"))
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions for MessageFormatTraceInterface
+// ----------------------------------------------------------------------------
+
+func TestMessageFormatTrace(test *testing.T) {
+	traceId := "4bf92f3577b34da6a3ce929d0e0e4736"
+	spanId := "00f067aa0ba902b7"
+	expected := `{"level":"INFO","id":"id-1","text":"text-1","location":"In main() at main.go:12","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7","details":{"1":"Bob"}}`
+	details := map[string]interface{}{"1": "Bob"}
+	for _, messageFormat := range []MessageFormatTraceInterface{&MessageFormatSenzing{}, &MessageFormatJson{}} {
+		actual, err := messageFormat.MessageWithTrace("", "", "INFO", "In main() at main.go:12", "id-1", "", "text-1", 0, nil, details, traceId, spanId)
+		testError(test, nil, err)
+		assert.Equal(test, expected, actual)
+		assert.NoError(test, ValidateSenzing(actual))
+	}
+	for _, testBinaryFormat := range testBinaryFormats {
+		message, err := testBinaryFormat.messageFormat.(MessageFormatTraceInterface).MessageWithTrace("", "", "INFO", "In main() at main.go:12", "id-1", "", "text-1", 0, nil, details, traceId, spanId)
+		testError(test, testBinaryFormat.messageFormat, err)
+		actual, err := testBinaryFormat.asJson(message)
+		testError(test, testBinaryFormat.messageFormat, err)
+		assert.Equal(test, expected, actual)
+	}
+
+	// Without a trace, the message is unchanged.
+
+	testObject := &MessageFormatSenzing{}
+	actual, err := testObject.MessageWithTrace("", "", "INFO", "", "id-1", "", "text-1", 0, nil, nil, "", "")
+	testError(test, testObject, err)
+	expectedMessage, err := testObject.Message("", "", "INFO", "", "id-1", "", "text-1", 0, nil, nil)
+	testError(test, testObject, err)
+	assert.Equal(test, expectedMessage, actual)
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions for MessageFormatCbor and MessageFormatMessagePack
+// ----------------------------------------------------------------------------
+
+type testBinaryFormat struct {
+	messageFormat MessageFormatInterface
+	asJson        func(message string) (string, error)
+	streamAsJson  func(reader io.Reader, writer io.Writer) error
+}
+
+var testBinaryFormats = []testBinaryFormat{
+	{&MessageFormatCbor{}, CborAsJson, CborStreamAsJson},
+	{&MessageFormatMessagePack{}, MessagePackAsJson, MessagePackStreamAsJson},
+}
+
+type testBinaryStruct struct {
+	Zulu  string `json:"zulu"`
+	Alpha int    `json:"alpha"`
+}
+
+type testBinaryTagStruct struct {
+	Name     string
+	Empty    string            `json:"empty,omitempty"`
+	Skipped  string            `json:"-"`
+	Quoted   int               `json:"quoted,string"`
+	Pointer  *testBinaryStruct `json:"pointer"`
+	Float    float32           `json:"float"`
+	internal string
+}
+
+type testBinaryEmbeddedStruct struct {
+	testBinaryStruct
+	Extra string `json:"extra"`
+}
+
+func TestMessageFormatBinary(test *testing.T) {
+	for _, binaryFormat := range testBinaryFormats {
+		for _, testCase := range testCases {
+			if len(testCase.expectedSenzing) > 0 {
+				test.Run(testCase.name, func(test *testing.T) {
+					message, err := binaryFormat.messageFormat.Message(testCase.date, testCase.time, testCase.level, testCase.location, testCase.id, testCase.status, testCase.text, testCase.duration, testCase.errors, testCase.details)
+					testError(test, binaryFormat.messageFormat, err)
+					actual, err := binaryFormat.asJson(message)
+					testError(test, binaryFormat.messageFormat, err)
+					assert.Equal(test, testCase.expectedSenzing, actual, testCase.name)
+				})
+			}
+		}
+	}
+}
+
+func TestMessageFormatBinaryValues(test *testing.T) {
+	many := map[string]interface{}{}
+	for index := 0; index < 20; index++ {
+		many[fmt.Sprintf("key-%02d", index)] = index * -1000
+	}
+	details := map[string]interface{}{
+		"array":    []interface{}{1, -1, -33, -200, -40000, -3000000000, 200, 70000, 5000000000, uint64(math.MaxUint64), 1.5, true, false, nil},
+		"json":     json.RawMessage(`{"zulu": 1, "alpha": [2, "<&>"], "big": 12345678901234567890, "small": -0.000001}`),
+		"many":     many,
+		"string":   strings.Repeat("x", 40),
+		"string16": strings.Repeat("y", 300),
+		"string32": strings.Repeat("z", 70000),
+		"struct":   &testBinaryStruct{Zulu: "z", Alpha: 1},
+		"unicode":  "Bj\u00f6rk \"\n\"",
+		"reflect": []interface{}{
+			map[string]int{"b": 2, "a": 1},
+			map[int]string{2: "two", 10: "ten"},
+			[]byte("bytes"),
+			[2]uint8{1, 2},
+			[]string(nil),
+			map[string]string(nil),
+			json.Number("12"),
+			float32(0.1),
+			time.Date(2000, 1, 2, 3, 4, 5, 6, time.UTC),
+			testBinaryTagStruct{Name: "n", Skipped: "s", Quoted: 7, Float: 0.1, internal: "i"},
+			testBinaryEmbeddedStruct{testBinaryStruct{Zulu: "z", Alpha: 1}, "e"},
+			&testBinaryTagStruct{Pointer: &testBinaryStruct{Zulu: "p"}},
+		},
+	}
+	testErrors := []interface{}{errors.New("error #1")}
+	for _, binaryFormat := range testBinaryFormats {
+		expected, err := (&MessageFormatSenzing{}).Message("date-1", "time-1", "INFO", "location-1", "id-1", "status-1", `{"text": 1}`, -11, testErrors, details)
+		testError(test, binaryFormat.messageFormat, err)
+		message, err := binaryFormat.messageFormat.Message("date-1", "time-1", "INFO", "location-1", "id-1", "status-1", `{"text": 1}`, -11, testErrors, details)
+		testError(test, binaryFormat.messageFormat, err)
+		assert.Less(test, len(message), len(expected))
+		actual, err := binaryFormat.asJson(message)
+		testError(test, binaryFormat.messageFormat, err)
+		assert.Equal(test, expected, actual)
+	}
+}
+
+func TestMessageFormatBinaryEncoding(test *testing.T) {
+	message, err := (&MessageFormatCbor{}).Message("", "", "INFO", "", "", "", "", 0, nil, nil)
+	testError(test, nil, err)
+	assert.Equal(test, "\xa1\x65level\x64INFO", message)
+	message, err = (&MessageFormatMessagePack{}).Message("", "", "INFO", "", "", "", "", 0, nil, nil)
+	testError(test, nil, err)
+	assert.Equal(test, "\x81\xa5level\xa4INFO", message)
+}
+
+func TestMessageFormatBinaryDecoding(test *testing.T) {
+	actual, err := CborAsJson("\xa2\x01\x42\x01\x02\xc1\xf9\x3c\x00\xf7")
+	testError(test, nil, err)
+	assert.Equal(test, `{"1":"AQI=","1":null}`, actual)
+	actual, err = MessagePackAsJson("\x82\x01\xc4\x02\x01\x02\xca\x3f\xc0\x00\x00\xd0\x80")
+	testError(test, nil, err)
+	assert.Equal(test, `{"1":"AQI=","1.5":-128}`, actual)
+
+	for _, message := range []string{"", "\xa1", "\xa1\x65lev", "\xbf", "\xf6\xf6"} {
+		_, err = CborAsJson(message)
+		assert.Error(test, err, message)
+	}
+	for _, message := range []string{"", "\x81", "\x81\xa5lev", "\xc1", "\xc0\xc0"} {
+		_, err = MessagePackAsJson(message)
+		assert.Error(test, err, message)
+	}
+}
+
+func TestMessageFormatBinaryStream(test *testing.T) {
+	for _, binaryFormat := range testBinaryFormats {
+		filename := filepath.Join(test.TempDir(), "messages")
+		file, err := os.Create(filename)
+		testError(test, binaryFormat.messageFormat, err)
+		fileLogger := log.New(file, "", 0)
+		var expected strings.Builder
+		for index, details := range []interface{}{nil, map[string]interface{}{"count": 10}, []interface{}{strings.Repeat("x", 70000)}} {
+			id := fmt.Sprintf("id-%d", index)
+			message, err := binaryFormat.messageFormat.Message("", "", "INFO", "", id, "", "", 0, nil, details)
+			testError(test, binaryFormat.messageFormat, err)
+			length, err := binaryFormat.messageFormat.(MessageFormatBinaryInterface).MessageLength([]byte(message + "\n"))
+			testError(test, binaryFormat.messageFormat, err)
+			assert.Equal(test, len(message), length)
+			fileLogger.Print(message)
+			jsonMessage, err := (&MessageFormatSenzing{}).Message("", "", "INFO", "", id, "", "", 0, nil, details)
+			testError(test, binaryFormat.messageFormat, err)
+			expected.WriteString(jsonMessage + "\n")
+		}
+		testError(test, binaryFormat.messageFormat, file.Close())
+
+		file, err = os.Open(filename)
+		testError(test, binaryFormat.messageFormat, err)
+		defer file.Close()
+		var actual strings.Builder
+		testError(test, binaryFormat.messageFormat, binaryFormat.streamAsJson(iotest.HalfReader(file), &actual))
+		assert.Equal(test, expected.String(), actual.String())
+
+		message, err := binaryFormat.messageFormat.Message("", "", "INFO", "", "", "", "", 0, nil, nil)
+		testError(test, binaryFormat.messageFormat, err)
+		actual.Reset()
+		err = binaryFormat.streamAsJson(strings.NewReader(message+message[:len(message)-1]), &actual)
+		assert.Error(test, err)
+		assert.Equal(test, `{"level":"INFO"}`+"\n", actual.String())
+	}
+}
+
+type testBenchmarkRecord struct {
+	Id       string            `json:"id"`
+	Names    []string          `json:"names"`
+	Features map[string]string `json:"features"`
+	Score    float64           `json:"score,omitempty"`
+	Active   bool              `json:"active"`
+}
+
+func BenchmarkMessageFormatBinary(benchmark *testing.B) {
+	details := map[string]interface{}{
+		"record": testBenchmarkRecord{
+			Id:       "1001",
+			Names:    []string{"Robert Smith", "Bob Smith"},
+			Features: map[string]string{"DOB": "1980-01-01", "PHONE": "555-1212", "ADDR": "123 Main St"},
+			Score:    0.75,
+			Active:   true,
+		},
+		"counts": map[string]int{"added": 3, "deleted": 1},
+		"tags":   []string{"alpha", "beta", "gamma"},
+	}
+	for _, messageFormat := range []MessageFormatInterface{&MessageFormatSenzing{}, &MessageFormatCbor{}, &MessageFormatMessagePack{}} {
+		benchmark.Run(fmt.Sprintf("%T", messageFormat), func(benchmark *testing.B) {
+			benchmark.ReportAllocs()
+			for index := 0; index < benchmark.N; index++ {
+				if _, err := messageFormat.Message("2000-01-01", "00:00:00.000000000", "INFO", "", "id-1", "", "text-1", 0, nil, details); err != nil {
+					benchmark.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions for MessageFormatCloudEvents
+// ----------------------------------------------------------------------------
+
+func TestMessageFormatCloudEvents(test *testing.T) {
+	testObject := &MessageFormatCloudEvents{Source: "https://example.com/loader"}
+	details := map[string]interface{}{"1": "Bob"}
+	actual, err := testObject.Message("2000-01-02", "03:04:05.600000000", "INFO", "", "senzing-99990004", "", "Bob", 0, nil, details)
+	testError(test, testObject, err)
+	var event map[string]interface{}
+	testError(test, testObject, json.Unmarshal([]byte(actual), &event))
+	assert.Regexp(test, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, event["id"])
+	expected := `{"specversion":"1.0","id":"` + event["id"].(string) + `","source":"https://example.com/loader","type":"com.senzing.log.senzing-99990004",` +
+		`"datacontenttype":"application/json","time":"2000-01-02T03:04:05.6Z",` +
+		`"data":{"date":"2000-01-02","time":"03:04:05.600000000","level":"INFO","id":"senzing-99990004","text":"Bob","details":{"1":"Bob"}}}`
+	assert.Equal(test, expected, actual)
+
+	second, err := testObject.Message("2000-01-02", "03:04:05.600000000", "INFO", "", "senzing-99990004", "", "Bob", 0, nil, details)
+	testError(test, testObject, err)
+	assert.NotEqual(test, actual, second)
+}
+
+func TestMessageFormatCloudEventsDefaults(test *testing.T) {
+	testObject := &MessageFormatCloudEvents{TypePrefix: "com.example"}
+	actual, err := testObject.Message("", "", "WARN", "", "", "", "text-1", 0, nil, nil)
+	testError(test, testObject, err)
+	var event map[string]interface{}
+	testError(test, testObject, json.Unmarshal([]byte(actual), &event))
+	assert.Equal(test, "com.example", event["type"])
+	assert.Equal(test, "/"+filepath.Base(os.Args[0]), event["source"])
+	assert.Equal(test, map[string]interface{}{"level": "WARN", "text": "text-1"}, event["data"])
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions for MessageFormatEcs
+// ----------------------------------------------------------------------------
+
+func TestMessageFormatEcs(test *testing.T) {
+	testObject := &MessageFormatEcs{}
+	errors := []interface{}{map[string]interface{}{"text": "error #1"}}
+	details := map[string]interface{}{"1": "Bob", "2": json.RawMessage(`123`)}
+	actual, err := testObject.Message("2000-01-02", "03:04:05.600000000", "ERROR", "In main.main() at main.go:12", "id-1", "status-1", "Bob knows Jane", 11, errors, details)
+	testError(test, testObject, err)
+	expected := `{"@timestamp":"2000-01-02T03:04:05.6Z","log.level":"ERROR","message":"Bob knows Jane","ecs.version":"8.11.0",` +
+		`"event":{"code":"id-1","duration":11},"log":{"origin":{"file":{"name":"main.go","line":12},"function":"main.main"}},` +
+		`"error":{"message":"error #1","type":"status-1"},"senzing":{"status":"status-1","details":{"1":"Bob","2":123}}}`
+	assert.Equal(test, expected, actual)
+}
+
+func TestMessageFormatEcsNamespace(test *testing.T) {
+	testObject := &MessageFormatEcs{Namespace: "app"}
+	errors := []interface{}{map[string]interface{}{"text": "error #1"}, map[string]interface{}{"text": "error #2"}}
+	actual, err := testObject.Message("2000-01-02", "03:04:05", "WARN", "location-1", "", "", "text-1", 0, errors, nil)
+	testError(test, testObject, err)
+	expected := `{"@timestamp":"2000-01-02T03:04:05Z","log.level":"WARN","message":"text-1","ecs.version":"8.11.0",` +
+		`"error":{"message":["error #1","error #2"]},"app":{"location":"location-1"}}`
+	assert.Equal(test, expected, actual)
+}
+
+func TestMessageFormatEcsTimestamp(test *testing.T) {
+	defer func() { timeNow = time.Now }()
+	timeNow = func() time.Time { return time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC) }
+	testObject := &MessageFormatEcs{}
+	actual, err := testObject.Message("", "", "INFO", "", "id-1", "", "", 0, nil, nil)
+	testError(test, testObject, err)
+	assert.Equal(test, `{"@timestamp":"2001-02-03T04:05:06Z","log.level":"INFO","ecs.version":"8.11.0","event":{"code":"id-1"}}`, actual)
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions for MessageFormatGelf
+// ----------------------------------------------------------------------------
+
+func TestMessageFormatGelf(test *testing.T) {
+	testObject := &MessageFormatGelf{Host: "host-1"}
+	errors := []interface{}{map[string]interface{}{"text": "error #1"}}
+	details := map[string]interface{}{"1": "Bob", "2": json.RawMessage(`123`), "id": "Jane", "status": json.RawMessage(`{"A":1}`), "a b": true}
+	actual, err := testObject.Message("2000-01-02", "03:04:05.600000", "ERROR", "location-1", "id-1", "status-1", "Bob knows Jane", 11, errors, details)
+	testError(test, testObject, err)
+	expected := `{"version":"1.1","host":"host-1","short_message":"Bob knows Jane","full_message":"Bob knows Jane\nerror #1","timestamp":946782245.600000,"level":3,` +
+		`"_1":"Bob","_2":123,"_a_b":"true","_detail_id":"Jane","_detail_status":"{\"A\":1}","_duration":11,"_location":"location-1","_message_id":"id-1","_status":"status-1"}`
+	assert.Equal(test, expected, actual)
+}
+
+func TestMessageFormatGelfMinimal(test *testing.T) {
+	testObject := &MessageFormatGelf{Host: "host-1"}
+	actual, err := testObject.Message("2000-01-02", "03:04:05", "WARN", "", "id-1", "", "", 0, nil, nil)
+	testError(test, testObject, err)
+	assert.Equal(test, `{"version":"1.1","host":"host-1","short_message":"id-1","timestamp":946782245.000000,"level":4,"_message_id":"id-1"}`, actual)
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions for MessageFormatSyslog
+// ----------------------------------------------------------------------------
+
+func TestMessageFormatSyslog(test *testing.T) {
+	timeNow = func() time.Time { return time.Date(2000, 1, 2, 3, 4, 5, 6000, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	testObject := &MessageFormatSyslog{
+		Hostname: "host",
+		AppName:  "app",
+		ProcId:   "1234",
+	}
+	actual, err := testObject.Message("", "", "WARN", "In main() at main.go:12", "senzing-99993001", "status-1", "Bob knows Jane", 0, []interface{}{map[string]string{"text": "err"}}, map[string]interface{}{"2": "Jane", "1": 123, "x=y": `a "quoted" ] value`})
+	testError(test, testObject, err)
+	assert.Equal(test, `<12>1 2000-01-02T03:04:05.000006Z host app 1234 senzing-99993001 [senzing@32473 status="status-1" location="In main() at main.go:12" errors="[{\"text\":\"err\"}\]"][details@32473 1="123" 2="Jane" x_y="a \"quoted\" \] value"] Bob knows Jane`, actual)
+
+	testObject.Facility = SyslogFacilityLocal0
+	actual, err = testObject.Message("", "", "", "", "", "", "", 0, nil, nil)
+	testError(test, testObject, err)
+	assert.Equal(test, `<134>1 2000-01-02T03:04:05.000006Z host app 1234 - -`, actual)
+
+	actual, err = testObject.Message("1999-12-31", "23:59:58.123456789", "", "", "", "", "", 0, nil, nil)
+	testError(test, testObject, err)
+	assert.Equal(test, `<134>1 1999-12-31T23:59:58.123456Z host app 1234 - -`, actual)
+}
+
+func TestSyslogSeverity(test *testing.T) {
+	levels := map[string]int{"TRACE": 7, "DEBUG": 7, "INFO": 6, "WARN": 4, "ERROR": 3, "FATAL": 2, "PANIC": 0, "": 6}
+	for level, expected := range levels {
+		assert.Equal(test, expected, SyslogSeverity(level), level)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions for MessageFormatJournald
+// ----------------------------------------------------------------------------
+
+func TestMessageFormatJournald(test *testing.T) {
+	testObject := &MessageFormatJournald{SyslogIdentifier: "app"}
+	actual, err := testObject.Message("", "", "ERROR", "In main() at main.go:12", "senzing-99994001", "status-1", "Bob knows Jane", 11, nil, map[string]interface{}{"1": 123, "recordId": "1001", "note": "two\nlines"})
+	testError(test, testObject, err)
+	expected := "MESSAGE=Bob knows Jane\n" +
+		"PRIORITY=3\n" +
+		"MESSAGE_ID=senzing-99994001\n" +
+		"SYSLOG_IDENTIFIER=app\n" +
+		"CODE_FILE=main.go\n" +
+		"CODE_LINE=12\n" +
+		"CODE_FUNC=main\n" +
+		"SENZING_STATUS=status-1\n" +
+		"SENZING_DURATION=11\n" +
+		"DETAIL_1=123\n" +
+		"DETAIL_NOTE\n\x09\x00\x00\x00\x00\x00\x00\x00two\nlines\n" +
+		"DETAIL_RECORDID=1001\n"
+	assert.Equal(test, expected, actual)
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions for MessageFormatOtlp
+// ----------------------------------------------------------------------------
+
+func TestMessageFormatOtlp(test *testing.T) {
+	defer func() { timeNow = time.Now }()
+	timeNow = func() time.Time { return time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC) }
+	testObject := &MessageFormatOtlp{}
+	errors := []interface{}{map[string]interface{}{"text": "error #1"}}
+	details := map[string]interface{}{"1": "Bob", "2": json.RawMessage(`{"b":[1,2.5,true,null]}`)}
+	actual, err := testObject.MessageWithTrace("2000-01-02", "03:04:05.600000000", "ERROR", "In main.main() at main.go:12", "id-1", "status-1", "Bob knows Jane", 11, errors, details, "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7")
+	testError(test, testObject, err)
+	expected := `{"timeUnixNano":"946782245600000000","observedTimeUnixNano":"981173106000000000","severityNumber":17,"severityText":"ERROR",` +
+		`"body":{"stringValue":"Bob knows Jane"},"attributes":[` +
+		`{"key":"senzing.id","value":{"stringValue":"id-1"}},` +
+		`{"key":"senzing.status","value":{"stringValue":"status-1"}},` +
+		`{"key":"senzing.duration","value":{"intValue":"11"}},` +
+		`{"key":"code.function","value":{"stringValue":"main.main"}},` +
+		`{"key":"code.filepath","value":{"stringValue":"main.go"}},` +
+		`{"key":"code.lineno","value":{"intValue":"12"}},` +
+		`{"key":"senzing.errors","value":{"arrayValue":{"values":[{"stringValue":"error #1"}]}}},` +
+		`{"key":"senzing.details.1","value":{"stringValue":"Bob"}},` +
+		`{"key":"senzing.details.2","value":{"kvlistValue":{"values":[{"key":"b","value":{"arrayValue":{"values":[{"intValue":"1"},{"doubleValue":2.5},{"boolValue":true},{}]}}}]}}}],` +
+		`"traceId":"4bf92f3577b34da6a3ce929d0e0e4736","spanId":"00f067aa0ba902b7"}`
+	assert.Equal(test, expected, actual)
+}
+
+func TestMessageFormatOtlpMinimal(test *testing.T) {
+	defer func() { timeNow = time.Now }()
+	timeNow = func() time.Time { return time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC) }
+	testObject := &MessageFormatOtlp{Namespace: "app"}
+	actual, err := testObject.Message("", "", "", "location-1", "", "", `{"A": 1}`, 0, nil, nil)
+	testError(test, testObject, err)
+	expected := `{"timeUnixNano":"981173106000000000","observedTimeUnixNano":"981173106000000000",` +
+		`"body":{"kvlistValue":{"values":[{"key":"A","value":{"intValue":"1"}}]}},` +
+		`"attributes":[{"key":"app.location","value":{"stringValue":"location-1"}}]}`
+	assert.Equal(test, expected, actual)
+}
+
+func TestOtlpSeverityNumber(test *testing.T) {
+	levels := map[string]int{"TRACE": 1, "DEBUG": 5, "INFO": 9, "WARN": 13, "ERROR": 17, "FATAL": 21, "PANIC": 24, "": 9}
+	for level, expected := range levels {
+		assert.Equal(test, expected, OtlpSeverityNumber(level), level)
+	}
+	assert.Equal(test, `{"doubleValue":"NaN"}`, string(otlpValue(math.NaN())))
+	assert.Equal(test, `{"stringValue":"18446744073709551615"}`, string(otlpValue(uint64(math.MaxUint64))))
+	expected := `{"arrayValue":{"values":[{"kvlistValue":{"values":[]}},{"arrayValue":{"values":[{}]}},` +
+		`{"kvlistValue":{"values":[{"key":"zulu","value":{"stringValue":"z"}},{"key":"alpha","value":{"intValue":"1"}}]}}]}}`
+	assert.Equal(test, expected, string(otlpValue([]interface{}{map[string]int{}, []interface{}{nil}, testBinaryStruct{Zulu: "z", Alpha: 1}})))
+}
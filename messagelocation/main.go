@@ -5,6 +5,14 @@ For examples of use, see https://github.com/Senzing/go-logging/blob/main/message
 */
 package messagelocation
 
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+)
+
 // ----------------------------------------------------------------------------
 // Types
 // ----------------------------------------------------------------------------
@@ -17,3 +25,51 @@ type CallerSkip int
 type MessageLocationInterface interface {
 	MessageLocation(messageNumber int, details ...interface{}) (string, error) // Get the "location" value from the messageNumber and details.
 }
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Matches the "In function() at file:line" values of MessageLocationDefault and MessageLocationSenzing.
+var locationRegexp = regexp.MustCompile(`^In (.*)\(\) at (.*):(\d+)$`)
+
+// Matches the last element of a qualified function name.
+var functionNameRegexp = regexp.MustCompile(`^.*\.(.*)$`)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The LocationFromPC function returns the "location" value, "In function() at file:line",
+of a program counter returned by runtime.Callers(), e.g. the PC of a slog.Record.
+If the program counter is zero or unknown, ok is false.
+*/
+func LocationFromPC(pc uintptr) (location string, ok bool) {
+	if pc == 0 {
+		return "", false
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	if len(frame.Function) == 0 {
+		return "", false
+	}
+	functionName := functionNameRegexp.ReplaceAllString(frame.Function, "$1")
+	return fmt.Sprintf("In %s() at %s:%d", functionName, filepath.Base(frame.File), frame.Line), true
+}
+
+/*
+The ParseLocation function splits a "location" value of the form "In function() at file:line"
+into the function name, file name, and line number.
+If location does not have that form, ok is false.
+*/
+func ParseLocation(location string) (function string, file string, line int, ok bool) {
+	match := locationRegexp.FindStringSubmatch(location)
+	if match == nil {
+		return "", "", 0, false
+	}
+	line, err := strconv.Atoi(match[3])
+	if err != nil {
+		return "", "", 0, false
+	}
+	return match[1], match[2], line, true
+}
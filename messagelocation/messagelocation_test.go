@@ -1,6 +1,7 @@
 package messagelocation
 
 import (
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -106,3 +107,37 @@ func TestMessageLocationSenzing(test *testing.T) {
 		}
 	}
 }
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestLocationFromPC(test *testing.T) {
+	var pcs [1]uintptr
+	runtime.Callers(1, pcs[:])
+	location, ok := LocationFromPC(pcs[0])
+	assert.True(test, ok)
+	assert.Contains(test, location, "In TestLocationFromPC() at messagelocation_test.go:")
+
+	_, ok = LocationFromPC(0)
+	assert.False(test, ok)
+}
+
+func TestParseLocation(test *testing.T) {
+	function, file, line, ok := ParseLocation("In AFunction() at somewhere.go:1234")
+	assert.True(test, ok)
+	assert.Equal(test, "AFunction", function)
+	assert.Equal(test, "somewhere.go", file)
+	assert.Equal(test, 1234, line)
+
+	testObject := &MessageLocationSenzing{CallerSkip: 1}
+	location, err := testObject.MessageLocation(1)
+	testError(test, testObject, err)
+	function, file, _, ok = ParseLocation(location)
+	assert.True(test, ok)
+	assert.Equal(test, "TestParseLocation", function)
+	assert.Equal(test, "messagelocation_test.go", file)
+
+	_, _, _, ok = ParseLocation("somewhere")
+	assert.False(test, ok)
+}
@@ -6,8 +6,12 @@ For examples of use, see https://github.com/Senzing/go-logging/blob/main/message
 package messagelogger
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"os"
 	"sync"
 
 	"github.com/senzing/go-logging/logger"
@@ -22,6 +26,7 @@ import (
 	"github.com/senzing/go-logging/messagestatus"
 	"github.com/senzing/go-logging/messagetext"
 	"github.com/senzing/go-logging/messagetime"
+	"github.com/senzing/go-logging/messagetrace"
 )
 
 // ----------------------------------------------------------------------------
@@ -53,6 +58,61 @@ type MessageLoggerInterface interface {
 	SetLogLevelFromString(levelString string) MessageLoggerInterface   // Sets the logger instance logging level using a string representation.
 }
 
+/*
+The MessageLoggerAsyncInterface type defines methods for the background writer of an asynchronous message logger,
+see Async. The message loggers created by New() implement it, with or without an *Async parameter.
+*/
+type MessageLoggerAsyncInterface interface {
+	Close() error    // Writes queued messages and stops asynchronous logging.
+	Dropped() uint64 // Returns the number of messages discarded by asynchronous logging.
+	Flush() error    // Waits until queued messages are written.
+}
+
+/*
+The MessageLoggerContextInterface type defines methods that add details extracted from a context.Context,
+see RegisterContextExtractor().
+The message loggers created by New() implement it. Example:
+
+	contextLogger := messageLogger.(messagelogger.MessageLoggerContextInterface)
+*/
+type MessageLoggerContextInterface interface {
+	ErrorContext(ctx context.Context, messageNumber int, details ...interface{}) error             // Like Error(), adding details extracted from the context.
+	LogContext(ctx context.Context, messageNumber int, details ...interface{}) error               // Like Log(), adding details extracted from the context.
+	MessageContext(ctx context.Context, messageNumber int, details ...interface{}) (string, error) // Like Message(), adding details extracted from the context.
+}
+
+/*
+The MessageLoggerWithInterface type defines a method for binding details to every message.
+The message loggers created by New() implement it, as do the message loggers that With() returns.
+*/
+type MessageLoggerWithInterface interface {
+	With(details ...interface{}) MessageLoggerInterface // Returns a logger that adds the details to every message.
+}
+
+/*
+The ContextExtractor type is a function that returns details to add to a message
+logged under a context, for example a request id stored in the context.
+See RegisterContextExtractor().
+*/
+type ContextExtractor func(ctx context.Context) []interface{}
+
+/*
+The MessageSink type is a destination for messages written by Log().
+Each sink has its own minimum level, taken from its Logger,
+and its own message format.
+If MessageFormat is nil, the message logger's MessageFormat is used.
+*/
+type MessageSink struct {
+	Logger        logger.LoggerInterface               // Destination. Its log level is the minimum level for the sink.
+	MessageFormat messageformat.MessageFormatInterface // For formatting messages sent to this sink.
+}
+
+// An io.Writer that leaves out the newline Go's log package adds after a binary message.
+type binaryWriter struct {
+	writer        io.Writer
+	messageFormat messageformat.MessageFormatBinaryInterface
+}
+
 // ----------------------------------------------------------------------------
 // Constants
 // ----------------------------------------------------------------------------
@@ -78,6 +138,9 @@ var (
 	isSystemLogLevelSet    = false
 	systemLogLevel         = LevelInfo
 	messageLoggerObservers = []MessageLoggerInterface{}
+	contextExtractorsLock  = &sync.RWMutex{}
+	contextExtractors      = []*ContextExtractor{}
+	osExit                 = os.Exit // Replaced in tests of FATAL messages.
 )
 
 // ----------------------------------------------------------------------------
@@ -108,16 +171,23 @@ func new(interfaces ...interface{}) (MessageLoggerInterface, error) {
 		MessageStatus: &messagestatus.MessageStatusNull{},
 		MessageText:   &messagetext.MessageTextNull{},
 		MessageTime:   &messagetime.MessageTimeNull{},
+		MessageTrace:  &messagetrace.MessageTraceNull{},
 	}
 
 	// Incorporate parameters.
 
 	var errorsList []interface{}
+	var async *Async
+	var writer io.Writer
+	var prefix *logger.Prefix
+	var flags *logger.Flags
+	isLoggerSupplied := false
 	if len(interfaces) > 0 {
 		for _, value := range interfaces {
 			switch typedValue := value.(type) {
 			case logger.LoggerInterface:
 				result.Logger = typedValue
+				isLoggerSupplied = true
 			case messagedate.MessageDateInterface:
 				result.MessageDate = typedValue
 			case messagedetails.MessageDetailsInterface:
@@ -140,6 +210,15 @@ func new(interfaces ...interface{}) (MessageLoggerInterface, error) {
 				result.MessageText = typedValue
 			case messagetime.MessageTimeInterface:
 				result.MessageTime = typedValue
+			case messagetrace.MessageTraceInterface:
+				result.MessageTrace = typedValue
+			case *Async:
+				async = typedValue
+			case *MessageSink:
+				result.MessageSinks = append(result.MessageSinks, typedValue)
+			case slog.Handler:
+				result.Logger = logger.NewSlog(typedValue)
+				isLoggerSupplied = true
 			case logger.Level:
 				logLevelCandidate, ok := value.(logger.Level)
 				if ok {
@@ -150,27 +229,77 @@ func new(interfaces ...interface{}) (MessageLoggerInterface, error) {
 				if ok {
 					logLevel = Level(logLevelCandidate)
 				}
+			case Name:
+				result.Name = string(typedValue)
+			case logger.Prefix:
+				prefix = &typedValue
+			case logger.Flags:
+				flags = &typedValue
+			case io.Writer:
+				writer = typedValue
 			default:
 				errorsList = append(errorsList, typedValue)
 			}
 		}
 	}
+
+	// Binary messages cannot follow a prefix or the date and time added by flags.
+	// Writing them to an io.Writer turns off both, unless given.
+
+	if _, ok := result.MessageFormat.(messageformat.MessageFormatBinaryInterface); ok {
+		if (prefix != nil && len(*prefix) > 0) || (flags != nil && *flags != 0) {
+			err = errors.New("logger.Prefix and logger.Flags must be empty for a binary message format")
+		} else if writer != nil {
+			emptyPrefix := logger.Prefix("")
+			noFlags := logger.Flags(0)
+			prefix = &emptyPrefix
+			flags = &noFlags
+		}
+	}
+
+	// Give the logger its own output, if requested.
+
+	if writer != nil || prefix != nil || flags != nil {
+		loggerDefault, ok := result.Logger.(*logger.LoggerDefault)
+		if isLoggerSupplied || !ok {
+			err = errors.New("io.Writer, logger.Prefix, and logger.Flags cannot be applied to a user-supplied logger.LoggerInterface")
+		} else {
+			if writer != nil {
+				loggerDefault.SetOutput(outputWriter(writer, result.MessageFormat))
+			}
+			if prefix != nil {
+				loggerDefault.SetPrefix(string(*prefix))
+			}
+			if flags != nil {
+				loggerDefault.SetFlags(int(*flags))
+			}
+		}
+	}
+	result.newLogLevel = logLevel
 	result.SetLogLevel(logLevel)
 
+	// Start the background writer, if requested.
+
+	if async != nil {
+		result.asyncWriter = newAsyncWriter(async)
+	}
+
 	// Report any unknown parameters.
 
 	if len(errorsList) > 0 {
 		err = fmt.Errorf("unsupported interfaces: %#v", errorsList)
 	}
 
-	// If system logging level set, set this logger to that level and
+	// If a name level or the system logging level is set, set this logger to that level and
 	// add this messageLogger to the Observers list.
 	// Do this in a thread-safe way.
 
 	lock.Lock()
 	defer lock.Unlock()
 
-	if isSystemLogLevelSet {
+	if level, ok := nameLogLevel(result.Name); ok {
+		result.SetLogLevel(level)
+	} else if isSystemLogLevelSet {
 		result.SetLogLevel(systemLogLevel)
 	}
 
@@ -190,8 +319,11 @@ To use non-default subcomponents,
 adding parameters to New() can specify the subcomponent desired.
 The parameters can be of the following type and in any order:
 
+  - io.Writer
+  - logger.Flags
   - logger.Level
   - logger.LoggerInterface
+  - logger.Prefix
   - messagedate.MessageDateInterface
   - messagedetails.MessageDetailsInterface
   - messageduration.MessageDurationInterface
@@ -203,18 +335,48 @@ The parameters can be of the following type and in any order:
   - messagestatus.MessageStatusInterface
   - messagetext.MessageTextInterface
   - messagetime.MessageTimeInterface
+  - messagetrace.MessageTraceInterface
+  - *Async
+  - Name
+  - *MessageSink
+  - slog.Handler
 
 If a type is specified multiple times,
 the last instance instance of the type specified wins.
+The exception is *MessageSink.
+Each *MessageSink is added to the list of destinations.
+
+The io.Writer, logger.Prefix, and logger.Flags parameters give the message logger
+its own output destination, line prefix, and log flags (https://pkg.go.dev/log#pkg-constants)
+instead of sharing those of Go's standard logger.
+They cannot be combined with a logger.LoggerInterface parameter.
+With a binary message format, such as messageformat.MessageFormatCbor, the prefix and flags must be empty,
+and they are when an io.Writer is given without them.
+A messageformat.MessageFormatConsole without an Output uses color only if the io.Writer is a terminal.
+
+A slog.Handler parameter is used as the logger.LoggerInterface, via logger.NewSlog().
+
+When *MessageSink parameters are given, Log() formats each message with the sink's MessageFormat
+and writes it to every sink whose Logger accepts the message level.
+The message logger's own level is checked first,
+so it should be at least as verbose as the most verbose sink.
+
+A Name parameter gives the message logger a dotted name for SetNameLogLevel().
+
+An *Async parameter makes Log() queue messages for a background writer.
+FATAL and PANIC messages are written after the queued messages.
+Call Close() before the program ends so queued messages are not lost.
 */
 func New(interfaces ...interface{}) (MessageLoggerInterface, error) {
 
 	messageDetails := &messagedetails.MessageDetailsDefault{}
 	messageErrors := &messageerrors.MessageErrorsDefault{}
+	messageTrace := &messagetrace.MessageTraceDefault{}
 
 	var newInterfaces = []interface{}{
 		messageDetails,
 		messageErrors,
+		messageTrace,
 	}
 
 	// Add other user-supplied interfaces to newInterfaces.
@@ -284,6 +446,7 @@ func NewSenzingLogger(productIdentifier int, idMessages map[int]string, interfac
 		IdMessages: idMessages,
 	}
 	messageTime := &messagetime.MessageTimeSenzing{}
+	messageTrace := &messagetrace.MessageTraceDefault{}
 
 	var newInterfaces = []interface{}{
 		messageDate,
@@ -297,6 +460,7 @@ func NewSenzingLogger(productIdentifier int, idMessages map[int]string, interfac
 		messageStatus,
 		messageText,
 		messageTime,
+		messageTrace,
 	}
 
 	// Add other user-supplied interfaces to newInterfaces.
@@ -339,15 +503,131 @@ func NewSenzingApiLogger(productIdentifier int, idMessages map[int]string, idSta
 	return NewSenzingLogger(productIdentifier, idMessages, newInterfaces...)
 }
 
+/*
+The NewWriterSink function returns a MessageSink that writes messages formatted by messageFormat to writer,
+such as the Writer of syslogsink, journaldsink, gelfsink, or otlpsink.
+Each message is one Write() ending with a newline, as added by Go's log package.
+Binary messages, such as those of messageformat.MessageFormatCbor, are written without the newline.
+A messageformat.MessageFormatConsole without an Output uses color only if writer is a terminal.
+The sink accepts every level; the message logger's level decides what is logged.
+To give the sink its own level, call SetLogLevel() on its Logger.
+Example:
+
+	messageLogger, _ := messagelogger.New(messagelogger.NewWriterSink(writer, &messageformat.MessageFormatSyslog{}))
+*/
+func NewWriterSink(writer io.Writer, messageFormat messageformat.MessageFormatInterface) *MessageSink {
+	return &MessageSink{
+		Logger:        logger.NewWithOutput(outputWriter(writer, messageFormat), "", 0).SetLogLevel(logger.LevelTrace),
+		MessageFormat: messageFormat,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Return writer, or for a binary message format, a writer that leaves out the newline after each message,
+so the output is a sequence of messages.
+A console message format without an Output is given writer, so that it decides on color for writer.
+*/
+func outputWriter(writer io.Writer, messageFormat messageformat.MessageFormatInterface) io.Writer {
+	if consoleFormat, ok := messageFormat.(*messageformat.MessageFormatConsole); ok && consoleFormat.Output == nil {
+		consoleFormat.Output = writer
+	}
+	if binaryFormat, ok := messageFormat.(messageformat.MessageFormatBinaryInterface); ok {
+		return &binaryWriter{
+			writer:        writer,
+			messageFormat: binaryFormat,
+		}
+	}
+	return writer
+}
+
+/*
+Set the system log level and propagate it to every message logger without a name level.
+The caller must hold lock.
+*/
+func setLogLevelLocked(level Level) {
+	isSystemLogLevelSet = true
+	systemLogLevel = level
+	for _, messageLogger := range messageLoggerObservers {
+		if _, ok := nameLogLevel(observerName(messageLogger)); !ok {
+			messageLogger.SetLogLevel(systemLogLevel)
+		}
+	}
+}
+
+// Append the trace context stored by messagetrace.NewContext() and the details from every registered ContextExtractor.
+func appendContextDetails(ctx context.Context, details []interface{}) []interface{} {
+	if ctx == nil {
+		return details
+	}
+	traceContext, hasTraceContext := messagetrace.FromContext(ctx)
+	contextExtractorsLock.RLock()
+	defer contextExtractorsLock.RUnlock()
+	if len(contextExtractors) == 0 && !hasTraceContext {
+		return details
+	}
+	result := make([]interface{}, 0, len(details)+len(contextExtractors)+1)
+	result = append(result, details...)
+	if hasTraceContext {
+		result = append(result, traceContext)
+	}
+	for _, contextExtractor := range contextExtractors {
+		result = append(result, (*contextExtractor)(ctx)...)
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+/*
+The Write method writes the message at the beginning of data, leaving out what follows it.
+Data that does not begin with a message, e.g. after a logger.Prefix, is written as is.
+*/
+func (binaryWriter *binaryWriter) Write(data []byte) (int, error) {
+	length, err := binaryWriter.messageFormat.MessageLength(data)
+	if err != nil {
+		length = len(data)
+	}
+	if _, err = binaryWriter.writer.Write(data[:length]); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
 // ----------------------------------------------------------------------------
 // Functions
 // ----------------------------------------------------------------------------
 
+/*
+The ContextValue function returns a ContextExtractor that adds the context value stored under key
+to the details as the named detail "name".
+Nothing is added when the context has no value for key.
+Example:
+
+	messagelogger.RegisterContextExtractor(messagelogger.ContextValue(requestIdKey, "requestId"))
+*/
+func ContextValue(key interface{}, name string) ContextExtractor {
+	return func(ctx context.Context) []interface{} {
+		value := ctx.Value(key)
+		if value == nil {
+			return nil
+		}
+		return []interface{}{map[string]string{name: fmt.Sprint(value)}}
+	}
+}
+
 /*
 The GetLogLevel will return the current system setting for the log level.
 */
 func GetLogLevel() (Level, error) {
 	var err error = nil
+	lock.Lock()
+	defer lock.Unlock()
 	if !isSystemLogLevelSet {
 		err = fmt.Errorf("system log level not set")
 	}
@@ -371,8 +651,40 @@ func GetLogLevelAsString() (string, error) {
 	return levelName, err
 }
 
+/*
+The RegisterContextExtractor function adds a ContextExtractor used by the XxxxContext() methods of every message logger.
+The extracted details are added after the details passed to the method,
+so they do not change the arguments used by message text templates.
+It returns a function that removes the ContextExtractor.
+Example:
+
+	unregister := messagelogger.RegisterContextExtractor(messagelogger.ContextValue(requestIdKey, "requestId"))
+	defer unregister()
+*/
+func RegisterContextExtractor(contextExtractor ContextExtractor) func() {
+	registered := &contextExtractor
+	contextExtractorsLock.Lock()
+	defer contextExtractorsLock.Unlock()
+	contextExtractors = append(contextExtractors, registered)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			contextExtractorsLock.Lock()
+			defer contextExtractorsLock.Unlock()
+			result := make([]*ContextExtractor, 0, len(contextExtractors))
+			for _, value := range contextExtractors {
+				if value != registered {
+					result = append(result, value)
+				}
+			}
+			contextExtractors = result
+		})
+	}
+}
+
 /*
 The SetLogLevel will set the current system setting for the log level.
+Message loggers with a level set by SetNameLogLevel() keep that level.
 */
 func SetLogLevel(level Level) error {
 	var err error = nil
@@ -381,14 +693,7 @@ func SetLogLevel(level Level) error {
 
 	lock.Lock()
 	defer lock.Unlock()
-
-	// Propagate level across all messageLoggers.
-
-	isSystemLogLevelSet = true
-	systemLogLevel = level
-	for _, messageLogger := range messageLoggerObservers {
-		messageLogger.SetLogLevel(systemLogLevel)
-	}
+	setLogLevelLocked(level)
 	return err
 }
 
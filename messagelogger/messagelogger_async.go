@@ -0,0 +1,181 @@
+/*
+The asynchronous mode of MessageLoggerDefault queues messages for a background writer,
+so Log() does not wait for formatting and output.
+*/
+package messagelogger
+
+import (
+	"errors"
+	"sync"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The OverflowPolicy type determines what Log() does when the queue of an asynchronous message logger is full.
+type OverflowPolicy int
+
+/*
+The Async type is a messagelogger.New() parameter that makes Log() asynchronous.
+The fields of each message, including "location", are determined by Log().
+Formatting and output happen on a background goroutine.
+Call Flush() to wait for queued messages to be written and Close() to stop the background goroutine.
+*/
+type Async struct {
+	QueueSize      int            // Maximum number of queued messages. If 0, DefaultAsyncQueueSize is used.
+	OverflowPolicy OverflowPolicy // What Log() does when the queue is full.
+}
+
+// A queued message.
+type asyncEntry struct {
+	messageLogger *MessageLoggerDefault // The logger, or child logger, that logged the message.
+	level         Level                 // The level of the message.
+	fields        *messageFields        // The fields of the message.
+}
+
+// The queue and background writer shared by a message logger and its child loggers.
+type asyncWriter struct {
+	mutex          sync.Mutex
+	notEmpty       *sync.Cond
+	notFull        *sync.Cond
+	idle           *sync.Cond
+	queue          []asyncEntry
+	queueSize      int
+	overflowPolicy OverflowPolicy
+	isWriting      bool
+	isClosed       bool
+	dropped        uint64
+	errorList      []error
+	done           chan struct{}
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// OverflowXxxx values are the policies for a full queue.
+const (
+	OverflowBlock      OverflowPolicy = iota // Wait for room in the queue.
+	OverflowDropNewest                       // Discard the message being logged.
+	OverflowDropOldest                       // Discard the oldest queued message.
+)
+
+// The queue size used when Async.QueueSize is not positive.
+const DefaultAsyncQueueSize = 1024
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+// Create the queue and start the background writer.
+func newAsyncWriter(async *Async) *asyncWriter {
+	result := &asyncWriter{
+		queueSize:      async.QueueSize,
+		overflowPolicy: async.OverflowPolicy,
+		done:           make(chan struct{}),
+	}
+	if result.queueSize <= 0 {
+		result.queueSize = DefaultAsyncQueueSize
+	}
+	result.notEmpty = sync.NewCond(&result.mutex)
+	result.notFull = sync.NewCond(&result.mutex)
+	result.idle = sync.NewCond(&result.mutex)
+	go result.run()
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+/*
+The enqueue method adds a message to the queue, applying the overflow policy if the queue is full.
+It returns false if the writer is closed, in which case the caller writes the message itself.
+*/
+func (writer *asyncWriter) enqueue(entry asyncEntry) bool {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	for !writer.isClosed && len(writer.queue) >= writer.queueSize {
+		switch writer.overflowPolicy {
+		case OverflowDropNewest:
+			writer.dropped++
+			return true
+		case OverflowDropOldest:
+			writer.queue[0] = asyncEntry{}
+			writer.queue = writer.queue[1:]
+			writer.dropped++
+		default:
+			writer.notFull.Wait()
+		}
+	}
+	if writer.isClosed {
+		return false
+	}
+	writer.queue = append(writer.queue, entry)
+	writer.notEmpty.Signal()
+	return true
+}
+
+// The run method writes queued messages until the writer is closed and the queue is empty.
+func (writer *asyncWriter) run() {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	defer close(writer.done)
+	for {
+		for len(writer.queue) == 0 && !writer.isClosed {
+			writer.notEmpty.Wait()
+		}
+		if len(writer.queue) == 0 {
+			writer.idle.Broadcast()
+			return
+		}
+		entry := writer.queue[0]
+		writer.queue[0] = asyncEntry{}
+		writer.queue = writer.queue[1:]
+		writer.isWriting = true
+		writer.notFull.Signal()
+		writer.mutex.Unlock()
+
+		err := entry.messageLogger.writeFields(entry.level, entry.fields)
+
+		writer.mutex.Lock()
+		if err != nil {
+			writer.errorList = append(writer.errorList, err)
+		}
+		writer.isWriting = false
+		if len(writer.queue) == 0 {
+			writer.idle.Broadcast()
+		}
+	}
+}
+
+// The flush method waits for the queue to be written and returns the errors seen since the last flush.
+func (writer *asyncWriter) flush() error {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	for len(writer.queue) > 0 || writer.isWriting {
+		writer.idle.Wait()
+	}
+	err := errors.Join(writer.errorList...)
+	writer.errorList = nil
+	return err
+}
+
+// The close method writes the queued messages and stops the background writer.
+func (writer *asyncWriter) close() error {
+	writer.mutex.Lock()
+	writer.isClosed = true
+	writer.notEmpty.Broadcast()
+	writer.notFull.Broadcast()
+	writer.mutex.Unlock()
+	<-writer.done
+	return writer.flush()
+}
+
+// The droppedCount method returns the number of messages discarded because the queue was full.
+func (writer *asyncWriter) droppedCount() uint64 {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	return writer.dropped
+}
@@ -4,7 +4,7 @@ The MessageLoggerDefault implementation aggregates id, status, text, and details
 package messagelogger
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"time"
 
@@ -20,6 +20,7 @@ import (
 	"github.com/senzing/go-logging/messagestatus"
 	"github.com/senzing/go-logging/messagetext"
 	"github.com/senzing/go-logging/messagetime"
+	"github.com/senzing/go-logging/messagetrace"
 )
 
 // ----------------------------------------------------------------------------
@@ -37,48 +38,387 @@ type MessageLoggerDefault struct {
 	MessageId       messageid.MessageIdInterface             // For "id" field value.
 	MessageLevel    messagelevel.MessageLevelInterface       // For "level" field value.
 	MessageLocation messagelocation.MessageLocationInterface // For "location" field value.
+	MessageSinks    []*MessageSink                           // If set, destinations used by Log() instead of Logger.
 	MessageStatus   messagestatus.MessageStatusInterface     // For "status" field value.
 	MessageText     messagetext.MessageTextInterface         // For "text" field value.
 	MessageTime     messagetime.MessageTimeInterface         // For "time" field value.
+	MessageTrace    messagetrace.MessageTraceInterface       // For "trace_id" and "span_id" field values.
+	Name            string                                   // Dotted name, e.g. "senzing.g2engine", for SetNameLogLevel().
+	asyncWriter     *asyncWriter                             // If set, queue used by Log(); see Async.
+	boundDetails    []interface{}                            // Details added by With() to every message.
+	newLogLevel     Level                                    // Log level given to New(), restored when name levels are cleared.
+}
+
+// The values of the fields in a message, before formatting.
+type messageFields struct {
+	date     string
+	time     string
+	level    string
+	logLevel logger.Level
+	location string
+	traceId  string
+	spanId   string
+	id       string
+	status   string
+	text     string
+	duration int64
+	errors   interface{}
+	details  interface{}
+}
+
+// A logger that writes a message at a level without exiting or panicking, as logger.LoggerDefault and logger.LoggerSlog do.
+type levelLogger interface {
+	Log(level logger.Level, v ...interface{}) logger.LoggerInterface
 }
 
 // ----------------------------------------------------------------------------
 // Internal methods
 // ----------------------------------------------------------------------------
 
+/*
+The messageFields method calculates the value of each field of a message.
+MessageLocationInterface implementations count stack frames,
+so every public method must call messageFields directly.
+The details must begin with the bound details, see bindDetails().
+*/
+func (messagelogger *MessageLoggerDefault) messageFields(messageNumber int, details ...interface{}) *messageFields {
+	var err error
+	now := time.Now()
+
+	date := ""
+	if messagelogger.MessageDate != nil {
+		date, _ = messagelogger.MessageDate.MessageDate(messageNumber, now, details...)
+	}
+
+	time := ""
+	if messagelogger.MessageTime != nil {
+		time, _ = messagelogger.MessageTime.MessageTime(messageNumber, now, details...)
+	}
+
+	id := fmt.Sprintf("%d", messageNumber)
+	if messagelogger.MessageId != nil {
+		id, err = messagelogger.MessageId.MessageId(messageNumber, details...)
+		if err != nil {
+			id = fmt.Sprintf("%d", messageNumber)
+		}
+	}
+
+	location := ""
+	if messagelogger.MessageLocation != nil {
+		location, _ = messagelogger.MessageLocation.MessageLocation(messageNumber, details...)
+	}
+
+	level := ""
+	logLevel := logger.LevelInfo
+	if messagelogger.MessageLevel != nil {
+		levelAsLevel, _ := messagelogger.MessageLevel.MessageLevel(messageNumber, details...)
+		logLevel = levelAsLevel
+		var ok bool
+		level, ok = logger.LevelToTextMap[levelAsLevel]
+		if !ok {
+			level = ""
+		}
+	}
+
+	status := ""
+	if messagelogger.MessageStatus != nil {
+		status, _ = messagelogger.MessageStatus.MessageStatus(messageNumber, details...)
+	}
+
+	text := ""
+	if messagelogger.MessageText != nil {
+		// Bound details are not arguments of the message text template.
+		text, _ = messagelogger.MessageText.MessageText(messageNumber, details[len(messagelogger.boundDetails):]...)
+	}
+
+	duration := int64(0)
+	if messagelogger.MessageDuration != nil {
+		duration, _ = messagelogger.MessageDuration.MessageDuration(messageNumber, details...)
+	}
+
+	var errors interface{}
+	if messagelogger.MessageErrors != nil {
+		errors, _ = messagelogger.MessageErrors.MessageErrors(messageNumber, details...)
+	}
+
+	var detailList interface{}
+	if messagelogger.MessageDetails != nil {
+		detailList, _ = messagelogger.MessageDetails.MessageDetails(messageNumber, details...)
+	}
+
+	traceContext := messagetrace.TraceContext{}
+	if messagelogger.MessageTrace != nil {
+		traceContext, _ = messagelogger.MessageTrace.MessageTrace(messageNumber, details...)
+	}
+
+	return &messageFields{
+		date:     date,
+		time:     time,
+		level:    level,
+		logLevel: logLevel,
+		location: location,
+		traceId:  traceContext.TraceId,
+		spanId:   traceContext.SpanId,
+		id:       id,
+		status:   status,
+		text:     text,
+		duration: duration,
+		errors:   errors,
+		details:  detailList,
+	}
+}
+
+// Prepend the details bound by With() to the details of a message.
+func (messagelogger *MessageLoggerDefault) bindDetails(details []interface{}) []interface{} {
+	if len(messagelogger.boundDetails) == 0 {
+		return details
+	}
+	result := make([]interface{}, 0, len(messagelogger.boundDetails)+len(details))
+	result = append(result, messagelogger.boundDetails...)
+	return append(result, details...)
+}
+
+// Create a *MessageError from the fields of a message.
+func (messagelogger *MessageLoggerDefault) errorFromFields(messageNumber int, fields *messageFields, details ...interface{}) error {
+	errorMessage, err := messagelogger.formatMessage(messagelogger.MessageFormat, fields)
+	if err != nil {
+		return err
+	}
+	return &MessageError{
+		MessageNumber: messageNumber,
+		Id:            fields.id,
+		Status:        fields.status,
+		Level:         fields.logLevel,
+		Details:       details,
+		message:       errorMessage,
+	}
+}
+
+// Write the message, built from its fields, to the logger or to the sinks.
+func (messagelogger *MessageLoggerDefault) logFields(messageNumber int, fields *messageFields, details ...interface{}) error {
+	var err error = nil
+	messageLevel := logger.LevelInfo
+	if messagelogger.MessageLevel != nil {
+		messageLevel, err = messagelogger.MessageLevel.MessageLevel(messageNumber, details...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return messagelogger.logFieldsAtLevel(Level(messageLevel), fields)
+}
+
+// Write the message, built from its fields, at the given level.
+func (messagelogger *MessageLoggerDefault) logFieldsAtLevel(level Level, fields *messageFields) error {
+	if !isLevelEnabled(messagelogger.Logger, level) {
+		return nil
+	}
+
+	// FATAL and PANIC messages are written after the queue, as the program exits or panics.
+
+	if messagelogger.asyncWriter != nil {
+		if level < Level(logger.LevelFatal) && messagelogger.asyncWriter.enqueue(asyncEntry{messageLogger: messagelogger, level: level, fields: fields}) {
+			return nil
+		}
+		messagelogger.asyncWriter.flush()
+	}
+	return messagelogger.writeFields(level, fields)
+}
+
+/*
+The logRecord method logs a message reported by another logging API, such as a slog.Record,
+at the given level and timestamp instead of the level in details and the current time.
+The "location" field reports pc, the caller of that API, instead of the caller of logRecord.
+*/
+func (messagelogger *MessageLoggerDefault) logRecord(ctx context.Context, messageNumber int, level logger.Level, timestamp time.Time, pc uintptr, details ...interface{}) error {
+	details = appendContextDetails(ctx, messagelogger.bindDetails(details))
+	fields := messagelogger.messageFields(messageNumber, details...)
+	if !timestamp.IsZero() {
+		if messagelogger.MessageDate != nil {
+			fields.date, _ = messagelogger.MessageDate.MessageDate(messageNumber, timestamp, details...)
+		}
+		if messagelogger.MessageTime != nil {
+			fields.time, _ = messagelogger.MessageTime.MessageTime(messageNumber, timestamp, details...)
+		}
+	}
+	switch messagelogger.MessageLocation.(type) {
+	case *messagelocation.MessageLocationDefault, *messagelocation.MessageLocationSenzing:
+		if location, ok := messagelocation.LocationFromPC(pc); ok {
+			fields.location = location
+		}
+	}
+	if messagelogger.MessageLevel != nil {
+		fields.level = logger.LevelToTextMap[level]
+		fields.logLevel = level
+	}
+	return messagelogger.logFieldsAtLevel(Level(level), fields)
+}
+
+// Format the message and write it to the logger or to the sinks.
+func (messagelogger *MessageLoggerDefault) writeFields(level Level, fields *messageFields) error {
+	if len(messagelogger.MessageSinks) > 0 {
+		return messagelogger.logToSinks(level, fields)
+	}
+	messageBody, err := messagelogger.formatMessage(messagelogger.MessageFormat, fields)
+	if err != nil {
+		return err
+	}
+	logBasedOnLevel(messagelogger.Logger, level, messageBody)
+	return err
+}
+
+// Create a message from its fields using the requested message format.
+// The trace context is only given to formats that implement messageformat.MessageFormatTraceInterface.
+func (messagelogger *MessageLoggerDefault) formatMessage(messageFormat messageformat.MessageFormatInterface, fields *messageFields) (string, error) {
+	if traceFormat, ok := messageFormat.(messageformat.MessageFormatTraceInterface); ok && len(fields.traceId) > 0 {
+		return traceFormat.MessageWithTrace(fields.date, fields.time, fields.level, fields.location, fields.id, fields.status, fields.text, fields.duration, fields.errors, fields.details, fields.traceId, fields.spanId)
+	}
+	result, err := messageFormat.Message(fields.date, fields.time, fields.level, fields.location, fields.id, fields.status, fields.text, fields.duration, fields.errors, fields.details)
+	if err != nil {
+		return "", err
+	}
+	return result, err
+}
+
+// Return true if the logger will write a message at the given level.
+func isLevelEnabled(aLogger logger.LoggerInterface, level Level) bool {
+	switch level {
+	case Level(logger.LevelTrace):
+		return aLogger.IsTrace()
+	case Level(logger.LevelDebug):
+		return aLogger.IsDebug()
+	case Level(logger.LevelWarn):
+		return aLogger.IsWarn()
+	case Level(logger.LevelError):
+		return aLogger.IsError()
+	case Level(logger.LevelFatal):
+		return aLogger.IsFatal()
+	case Level(logger.LevelPanic):
+		return aLogger.IsPanic()
+	default:
+		return aLogger.IsInfo()
+	}
+}
+
+/*
+Write a message at a level without exiting or panicking.
+A logger without a Log() method writes FATAL and PANIC messages with Error().
+*/
+func logWithoutExiting(aLogger logger.LoggerInterface, level Level, messageBody string) {
+	if levelLogger, ok := aLogger.(levelLogger); ok {
+		levelLogger.Log(logger.Level(level), messageBody)
+		return
+	}
+	switch level {
+	case Level(logger.LevelFatal), Level(logger.LevelPanic):
+		aLogger.Error(messageBody)
+	default:
+		logBasedOnLevel(aLogger, level, messageBody)
+	}
+}
+
 // Write log record based on message level method.
-func (messagelogger *MessageLoggerDefault) logBasedOnLevel(level Level, messageBody string) {
+func logBasedOnLevel(aLogger logger.LoggerInterface, level Level, messageBody string) {
 	switch level {
 	case Level(logger.LevelInfo):
-		messagelogger.Logger.Info(messageBody)
+		aLogger.Info(messageBody)
 	case Level(logger.LevelWarn):
-		messagelogger.Logger.Warn(messageBody)
+		aLogger.Warn(messageBody)
 	case Level(logger.LevelError):
-		messagelogger.Logger.Error(messageBody)
+		aLogger.Error(messageBody)
 	case Level(logger.LevelDebug):
-		messagelogger.Logger.Debug(messageBody)
+		aLogger.Debug(messageBody)
 	case Level(logger.LevelTrace):
-		messagelogger.Logger.Trace(messageBody)
+		aLogger.Trace(messageBody)
 	case Level(logger.LevelFatal):
-		messagelogger.Logger.Fatal(messageBody)
+		aLogger.Fatal(messageBody)
 	case Level(logger.LevelPanic):
-		messagelogger.Logger.Panic(messageBody)
+		aLogger.Panic(messageBody)
 	default:
-		messagelogger.Logger.Info(messageBody)
+		aLogger.Info(messageBody)
 	}
 }
 
+/*
+The logToSinks method formats the message for each sink and writes it to each sink that accepts the level.
+A FATAL or PANIC message is written to every accepting sink without exiting or panicking,
+then the program exits or panics once, as Fatal() or Panic() of a single logger would.
+*/
+func (messagelogger *MessageLoggerDefault) logToSinks(level Level, fields *messageFields) error {
+	var err error = nil
+	var messageBody string
+	isWritten := false
+	for _, messageSink := range messagelogger.MessageSinks {
+		if !isLevelEnabled(messageSink.Logger, level) {
+			continue
+		}
+		messageFormat := messageSink.MessageFormat
+		if messageFormat == nil {
+			messageFormat = messagelogger.MessageFormat
+		}
+		sinkMessageBody, formatErr := messagelogger.formatMessage(messageFormat, fields)
+		if formatErr != nil {
+			err = formatErr
+			continue
+		}
+		messageBody = sinkMessageBody
+		logWithoutExiting(messageSink.Logger, level, messageBody)
+		isWritten = true
+	}
+	if !isWritten {
+		return err
+	}
+	switch level {
+	case Level(logger.LevelFatal):
+		osExit(1)
+	case Level(logger.LevelPanic):
+		panic(messageBody)
+	}
+	return err
+}
+
 // ----------------------------------------------------------------------------
 // Interface methods
 // ----------------------------------------------------------------------------
 
-// The Error method returns an error with the formatted message.
+// The Close method writes queued messages and stops the background writer of an asynchronous message logger.
+// Later messages are written synchronously.
+func (messagelogger *MessageLoggerDefault) Close() error {
+	if messagelogger.asyncWriter == nil {
+		return nil
+	}
+	return messagelogger.asyncWriter.close()
+}
+
+// The Dropped method returns the number of messages an asynchronous message logger discarded because its queue was full.
+func (messagelogger *MessageLoggerDefault) Dropped() uint64 {
+	if messagelogger.asyncWriter == nil {
+		return 0
+	}
+	return messagelogger.asyncWriter.droppedCount()
+}
+
+// The Error method returns a *MessageError with the formatted message.
 func (messagelogger *MessageLoggerDefault) Error(messageNumber int, details ...interface{}) error {
-	errorMessage, err := messagelogger.Message(messageNumber, details...)
-	if err != nil {
-		return err
+	details = messagelogger.bindDetails(details)
+	fields := messagelogger.messageFields(messageNumber, details...)
+	return messagelogger.errorFromFields(messageNumber, fields, details...)
+}
+
+// The ErrorContext method is like Error, adding details extracted from ctx.
+func (messagelogger *MessageLoggerDefault) ErrorContext(ctx context.Context, messageNumber int, details ...interface{}) error {
+	details = appendContextDetails(ctx, messagelogger.bindDetails(details))
+	fields := messagelogger.messageFields(messageNumber, details...)
+	return messagelogger.errorFromFields(messageNumber, fields, details...)
+}
+
+// The Flush method waits until an asynchronous message logger has written its queued messages.
+// The errors from writing those messages are returned.
+func (messagelogger *MessageLoggerDefault) Flush() error {
+	if messagelogger.asyncWriter == nil {
+		return nil
 	}
-	return errors.New(errorMessage)
+	return messagelogger.asyncWriter.flush()
 }
 
 // The GetLogLevel method returns the current log level as a typed int.
@@ -127,93 +467,32 @@ func (messagelogger *MessageLoggerDefault) IsWarn() bool {
 }
 
 // The Log method sends the formatted message to the Go log framework.
+// If MessageSinks are set, the message is formatted and sent to each sink instead.
 func (messagelogger *MessageLoggerDefault) Log(messageNumber int, details ...interface{}) error {
+	details = messagelogger.bindDetails(details)
+	fields := messagelogger.messageFields(messageNumber, details...)
+	return messagelogger.logFields(messageNumber, fields, details...)
+}
 
-	messageBody, err := messagelogger.Message(messageNumber, details...)
-	if err != nil {
-		return err
-	}
-
-	messageLevel := logger.LevelInfo
-	if messagelogger.MessageLevel != nil {
-		messageLevel, err = messagelogger.MessageLevel.MessageLevel(messageNumber, details...)
-		if err != nil {
-			return err
-		}
-	}
-	messagelogger.logBasedOnLevel(Level(messageLevel), messageBody)
-	return err
+// The LogContext method is like Log, adding details extracted from ctx.
+func (messagelogger *MessageLoggerDefault) LogContext(ctx context.Context, messageNumber int, details ...interface{}) error {
+	details = appendContextDetails(ctx, messagelogger.bindDetails(details))
+	fields := messagelogger.messageFields(messageNumber, details...)
+	return messagelogger.logFields(messageNumber, fields, details...)
 }
 
 // The Message method returns a string with the formatted message.
 func (messagelogger *MessageLoggerDefault) Message(messageNumber int, details ...interface{}) (string, error) {
-	var err error
-	now := time.Now()
-
-	date := ""
-	if messagelogger.MessageDate != nil {
-		date, _ = messagelogger.MessageDate.MessageDate(messageNumber, now, details...)
-	}
-
-	time := ""
-	if messagelogger.MessageTime != nil {
-		time, _ = messagelogger.MessageTime.MessageTime(messageNumber, now, details...)
-	}
-
-	id := fmt.Sprintf("%d", messageNumber)
-	if messagelogger.MessageId != nil {
-		id, err = messagelogger.MessageId.MessageId(messageNumber, details...)
-		if err != nil {
-			id = fmt.Sprintf("%d", messageNumber)
-		}
-	}
-
-	location := ""
-	if messagelogger.MessageLocation != nil {
-		location, _ = messagelogger.MessageLocation.MessageLocation(messageNumber, details...)
-	}
-
-	level := ""
-	if messagelogger.MessageLevel != nil {
-		levelAsLevel, _ := messagelogger.MessageLevel.MessageLevel(messageNumber, details...)
-		var ok bool
-		level, ok = logger.LevelToTextMap[levelAsLevel]
-		if !ok {
-			level = ""
-		}
-	}
-
-	status := ""
-	if messagelogger.MessageStatus != nil {
-		status, _ = messagelogger.MessageStatus.MessageStatus(messageNumber, details...)
-	}
-
-	text := ""
-	if messagelogger.MessageText != nil {
-		text, _ = messagelogger.MessageText.MessageText(messageNumber, details...)
-	}
-
-	duration := int64(0)
-	if messagelogger.MessageDuration != nil {
-		duration, _ = messagelogger.MessageDuration.MessageDuration(messageNumber, details...)
-	}
-
-	var errors interface{}
-	if messagelogger.MessageErrors != nil {
-		errors, _ = messagelogger.MessageErrors.MessageErrors(messageNumber, details...)
-	}
-
-	var detailList interface{}
-	if messagelogger.MessageDetails != nil {
-		detailList, _ = messagelogger.MessageDetails.MessageDetails(messageNumber, details...)
-	}
-
-	result, err := messagelogger.MessageFormat.Message(date, time, level, location, id, status, text, duration, errors, detailList)
-	if err != nil {
-		return "", err
-	}
+	details = messagelogger.bindDetails(details)
+	fields := messagelogger.messageFields(messageNumber, details...)
+	return messagelogger.formatMessage(messagelogger.MessageFormat, fields)
+}
 
-	return result, err
+// The MessageContext method is like Message, adding details extracted from ctx.
+func (messagelogger *MessageLoggerDefault) MessageContext(ctx context.Context, messageNumber int, details ...interface{}) (string, error) {
+	details = appendContextDetails(ctx, messagelogger.bindDetails(details))
+	fields := messagelogger.messageFields(messageNumber, details...)
+	return messagelogger.formatMessage(messagelogger.MessageFormat, fields)
 }
 
 // The SetLogLevel method sets the log level given a typed int.
@@ -228,3 +507,16 @@ func (messagelogger *MessageLoggerDefault) SetLogLevelFromString(levelString str
 	messagelogger.Logger.SetLogLevelFromString(levelString)
 	return messagelogger
 }
+
+/*
+The With method returns a message logger that adds details to every message.
+The new logger shares the components and log level of messagelogger.
+The details are placed before the details of each message,
+so their keys in the "details" field do not change from message to message.
+They are not used as arguments of the message text template.
+*/
+func (messagelogger *MessageLoggerDefault) With(details ...interface{}) MessageLoggerInterface {
+	result := *messagelogger
+	result.boundDetails = messagelogger.bindDetails(details)
+	return &result
+}
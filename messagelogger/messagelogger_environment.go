@@ -0,0 +1,189 @@
+/*
+Configuration of a message logger from environment variables.
+*/
+package messagelogger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/senzing/go-logging/logger"
+	"github.com/senzing/go-logging/messageformat"
+	"github.com/senzing/go-logging/messagelocation"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// EnvXxxx values are the environment variables read by NewFromEnvironment().
+const (
+	EnvLogCallerSkip = "SENZING_TOOLS_LOG_CALLER_SKIP"
+	EnvLogFormat     = "SENZING_TOOLS_LOG_FORMAT"
+	EnvLogLevel      = "SENZING_TOOLS_LOG_LEVEL"
+	EnvLogLocation   = "SENZING_TOOLS_LOG_LOCATION"
+	EnvLogOutput     = "SENZING_TOOLS_LOG_OUTPUT"
+)
+
+// The runtime.Caller() skip that reaches the caller of a MessageLoggerDefault method:
+// MessageLocation(), messageFields(), then the method.
+const callerSkipToCaller = 3
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Opens the file named by SENZING_TOOLS_LOG_OUTPUT. Replaced in tests.
+var openFile = os.OpenFile
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return true if the parameters of New() give the message logger a destination.
+func isOutputSupplied(interfaces []interface{}) bool {
+	for _, value := range interfaces {
+		switch value.(type) {
+		case logger.LoggerInterface, slog.Handler, *MessageSink, io.Writer:
+			return true
+		}
+	}
+	return false
+}
+
+// Close the file opened for SENZING_TOOLS_LOG_OUTPUT, if any, among the parameters set by environment variables.
+func closeEnvironmentOutput(interfaces []interface{}) {
+	for _, value := range interfaces {
+		if file, ok := value.(*os.File); ok && file != os.Stderr && file != os.Stdout {
+			_ = file.Close()
+		}
+	}
+}
+
+// Return the parameters of New() set by environment variables.
+func environmentInterfaces(isOutputSupplied bool) ([]interface{}, error) {
+	var result []interface{}
+	var errorList []error
+	invalid := func(name string, value string, err error) {
+		errorList = append(errorList, fmt.Errorf("invalid %s %q: %w", name, value, err))
+	}
+
+	if value := os.Getenv(EnvLogLevel); len(value) > 0 {
+		level, err := parseLevel(value)
+		if err != nil {
+			invalid(EnvLogLevel, value, err)
+		} else {
+			result = append(result, level)
+		}
+	}
+
+	if value := os.Getenv(EnvLogFormat); len(value) > 0 {
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "default":
+			result = append(result, &messageformat.MessageFormatDefault{})
+		case "json":
+			result = append(result, &messageformat.MessageFormatJson{})
+		case "logfmt":
+			result = append(result, &messageformat.MessageFormatLogfmt{})
+		case "senzing":
+			result = append(result, &messageformat.MessageFormatSenzing{})
+		default:
+			invalid(EnvLogFormat, value, errors.New("format must be default, json, logfmt, or senzing"))
+		}
+	}
+
+	callerSkip := 0
+	if value := os.Getenv(EnvLogCallerSkip); len(value) > 0 {
+		var err error
+		callerSkip, err = strconv.Atoi(strings.TrimSpace(value))
+		if err == nil && callerSkip < 0 {
+			err = errors.New("caller skip must not be negative")
+		}
+		if err != nil {
+			invalid(EnvLogCallerSkip, value, err)
+		}
+	}
+	isLocation := false
+	if value := os.Getenv(EnvLogLocation); len(value) > 0 {
+		var err error
+		isLocation, err = strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			invalid(EnvLogLocation, value, err)
+		} else if isLocation {
+			result = append(result, &messagelocation.MessageLocationDefault{CallerSkip: callerSkipToCaller + callerSkip})
+		}
+	}
+	if value := os.Getenv(EnvLogCallerSkip); len(value) > 0 && !isLocation && len(errorList) == 0 {
+		invalid(EnvLogCallerSkip, value, fmt.Errorf("caller skip requires %s=true", EnvLogLocation))
+	}
+
+	// Open a file only when the configuration is valid.
+
+	if value := os.Getenv(EnvLogOutput); len(value) > 0 && !isOutputSupplied && len(errorList) == 0 {
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "stderr":
+			result = append(result, os.Stderr)
+		case "stdout":
+			result = append(result, os.Stdout)
+		default:
+			file, err := openFile(value, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+			if err != nil {
+				invalid(EnvLogOutput, value, err)
+			} else {
+				result = append(result, file)
+			}
+		}
+	}
+
+	return result, errors.Join(errorList...)
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+The NewFromEnvironment function creates a new instance of MessageLoggerDefault, like New(),
+configured by these environment variables:
+
+  - SENZING_TOOLS_LOG_LEVEL: TRACE, DEBUG, INFO, WARN, ERROR, FATAL, or PANIC.
+  - SENZING_TOOLS_LOG_FORMAT: default, json, logfmt, or senzing.
+  - SENZING_TOOLS_LOG_OUTPUT: stderr, stdout, or the path of a file to append to.
+    The file stays open for the life of the program.
+  - SENZING_TOOLS_LOG_LOCATION: true to add the "location" field. See strconv.ParseBool() for the values.
+  - SENZING_TOOLS_LOG_CALLER_SKIP: for code that wraps a message logger,
+    the number of stack frames above the caller of Log() to report in the "location" field. The default is 0.
+    It requires SENZING_TOOLS_LOG_LOCATION=true.
+
+Values are not case sensitive. Unset or empty variables are ignored.
+
+The parameters are the same as for New() and override the environment,
+e.g. a logger.Level parameter replaces SENZING_TOOLS_LOG_LEVEL
+and an io.Writer, logger.LoggerInterface, slog.Handler, or *MessageSink parameter replaces SENZING_TOOLS_LOG_OUTPUT.
+
+An invalid value is reported as an error naming the variable, and no message logger is returned.
+If New() fails, the file opened for SENZING_TOOLS_LOG_OUTPUT is closed and no message logger is returned.
+*/
+func NewFromEnvironment(interfaces ...interface{}) (MessageLoggerInterface, error) {
+	environment, err := environmentInterfaces(isOutputSupplied(interfaces))
+	if err != nil {
+		return nil, err
+	}
+
+	// Add other user-supplied interfaces after the environment, so they win.
+
+	newInterfaces := make([]interface{}, 0, len(environment)+len(interfaces))
+	newInterfaces = append(newInterfaces, environment...)
+	newInterfaces = append(newInterfaces, interfaces...)
+	result, err := New(newInterfaces...)
+	if err != nil {
+		closeEnvironmentOutput(environment)
+		return nil, err
+	}
+	return result, nil
+}
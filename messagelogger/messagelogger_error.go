@@ -0,0 +1,52 @@
+/*
+The MessageError implementation is the error returned by MessageLoggerDefault.Error().
+*/
+package messagelogger
+
+import (
+	"github.com/senzing/go-logging/logger"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The MessageError type is an error that keeps the parts of the message it was created from.
+Error() returns the formatted message.
+The errors found in Details can be reached using errors.Is() and errors.As().
+Example of routing on status:
+
+	var messageError *messagelogger.MessageError
+	if errors.As(err, &messageError) && messageError.Status == messagestatus.ErrorRetryable {
+		// Retry.
+	}
+*/
+type MessageError struct {
+	MessageNumber int           // Message number passed to Error().
+	Id            string        // Value of the "id" field.
+	Status        string        // Value of the "status" field.
+	Level         logger.Level  // Log level of the message.
+	Details       []interface{} // Details passed to Error().
+	message       string        // Formatted message.
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Error method returns the formatted message.
+func (messageError *MessageError) Error() string {
+	return messageError.message
+}
+
+// The Unwrap method returns the errors found in Details.
+func (messageError *MessageError) Unwrap() []error {
+	var result []error
+	for _, detail := range messageError.Details {
+		if err, ok := detail.(error); ok {
+			result = append(result, err)
+		}
+	}
+	return result
+}
@@ -0,0 +1,213 @@
+/*
+Runtime control of log levels: an http.Handler and, on Unix, signals.
+*/
+package messagelogger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/senzing/go-logging/logger"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The LevelHandler type is an http.Handler for reading and changing log levels at runtime.
+
+  - GET returns the system log level, e.g. {"level":"INFO"}.
+  - PUT or POST sets the system log level with SetLogLevel(), which changes every message logger.
+    The level is the "level" query parameter, or the body: {"level":"DEBUG"} or DEBUG.
+
+With the "logger" query parameter, e.g. ?logger=loader,
+a request reads or changes only the message logger of that name in Loggers:
+{"logger":"loader","level":"DEBUG"}.
+Errors are returned as {"error":"..."}.
+Example:
+
+	http.Handle("/loglevel", &messagelogger.LevelHandler{
+		Loggers: map[string]messagelogger.MessageLoggerInterface{"loader": loaderLogger},
+	})
+*/
+type LevelHandler struct {
+	Loggers map[string]MessageLoggerInterface // Message loggers that a request can name with the "logger" query parameter.
+}
+
+// The body of a LevelHandler request or response.
+type levelMessage struct {
+	Logger string `json:"logger,omitempty"`
+	Level  string `json:"level,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The largest request body read by LevelHandler.
+const maxLevelRequestSize = 4096
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return the level named by levelName, in any case.
+func parseLevel(levelName string) (Level, error) {
+	level, ok := logger.TextToLevelMap[strings.ToUpper(strings.TrimSpace(levelName))]
+	if !ok {
+		return LevelInfo, fmt.Errorf("unknown log level name: %s", levelName)
+	}
+	return Level(level), nil
+}
+
+// Return the name of the system log level.
+func systemLogLevelName() string {
+	level, _ := GetLogLevel()
+	return logger.LevelToTextMap[logger.Level(level)]
+}
+
+/*
+Change the system log level by step levels, staying within LevelTrace and LevelPanic.
+A negative step makes logging more verbose.
+The level is read and changed under one hold of lock, so concurrent steps are not lost.
+*/
+func stepLogLevel(step int) Level {
+	lock.Lock()
+	defer lock.Unlock()
+	level := systemLogLevel + Level(step)
+	if level < LevelTrace {
+		level = LevelTrace
+	}
+	if level > LevelPanic {
+		level = LevelPanic
+	}
+	setLogLevelLocked(level)
+	return level
+}
+
+// Write a LevelHandler response.
+func writeLevelMessage(response http.ResponseWriter, status int, message *levelMessage) {
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(status)
+	_ = json.NewEncoder(response).Encode(message)
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The ServeHTTP method reads the log level on GET and changes it on PUT or POST.
+func (handler *LevelHandler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	var messageLogger MessageLoggerInterface
+	loggerName := request.URL.Query().Get("logger")
+	if len(loggerName) > 0 {
+		var ok bool
+		messageLogger, ok = handler.Loggers[loggerName]
+		if !ok {
+			writeLevelMessage(response, http.StatusNotFound, &levelMessage{Error: fmt.Sprintf("unknown logger: %s", loggerName)})
+			return
+		}
+	}
+
+	switch request.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPut, http.MethodPost:
+		levelName := request.URL.Query().Get("level")
+		if len(levelName) == 0 {
+			body, err := io.ReadAll(io.LimitReader(request.Body, maxLevelRequestSize))
+			if err != nil {
+				writeLevelMessage(response, http.StatusBadRequest, &levelMessage{Error: err.Error()})
+				return
+			}
+			var requestMessage levelMessage
+			if json.Unmarshal(body, &requestMessage) == nil {
+				levelName = requestMessage.Level
+			} else {
+				levelName = string(body)
+			}
+		}
+		level, err := parseLevel(levelName)
+		if err != nil {
+			writeLevelMessage(response, http.StatusBadRequest, &levelMessage{Error: err.Error()})
+			return
+		}
+		if messageLogger != nil {
+			messageLogger.SetLogLevel(level)
+		} else if err := SetLogLevel(level); err != nil {
+			writeLevelMessage(response, http.StatusInternalServerError, &levelMessage{Error: err.Error()})
+			return
+		}
+	default:
+		response.Header().Set("Allow", "GET, HEAD, POST, PUT")
+		writeLevelMessage(response, http.StatusMethodNotAllowed, &levelMessage{Error: fmt.Sprintf("unsupported method: %s", request.Method)})
+		return
+	}
+
+	if messageLogger != nil {
+		writeLevelMessage(response, http.StatusOK, &levelMessage{Logger: loggerName, Level: messageLogger.GetLogLevelAsString()})
+		return
+	}
+	writeLevelMessage(response, http.StatusOK, &levelMessage{Level: systemLogLevelName()})
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The HandleLevelSignals function changes the system log level when the process receives a signal:
+SIGUSR1 makes logging more verbose, e.g. INFO to DEBUG, and SIGUSR2 makes it less verbose.
+Signals are not handled until HandleLevelSignals() is called.
+It returns a function that stops handling the signals.
+An error is returned on platforms without SIGUSR1 and SIGUSR2, such as Windows.
+Example:
+
+	stop, err := messagelogger.HandleLevelSignals()
+	if err != nil {
+		...
+	}
+	defer stop()
+
+Then, from a shell:
+
+	kill -USR1 <pid>
+*/
+func HandleLevelSignals() (func(), error) {
+	if moreVerboseSignal == nil || lessVerboseSignal == nil {
+		return nil, errors.New("log level signals are not supported on " + runtime.GOOS)
+	}
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, moreVerboseSignal, lessVerboseSignal)
+	go func() {
+		for {
+			select {
+			case received := <-signals:
+				if received == moreVerboseSignal {
+					stepLogLevel(-1)
+				} else {
+					stepLogLevel(1)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}, nil
+}
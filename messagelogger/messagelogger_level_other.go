@@ -0,0 +1,17 @@
+//go:build !unix
+
+package messagelogger
+
+import (
+	"os"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// There are no SIGUSR1 and SIGUSR2 signals for HandleLevelSignals().
+var (
+	moreVerboseSignal os.Signal
+	lessVerboseSignal os.Signal
+)
@@ -0,0 +1,18 @@
+//go:build unix
+
+package messagelogger
+
+import (
+	"os"
+	"syscall"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// The signals handled by HandleLevelSignals().
+var (
+	moreVerboseSignal os.Signal = syscall.SIGUSR1
+	lessVerboseSignal os.Signal = syscall.SIGUSR2
+)
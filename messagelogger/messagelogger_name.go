@@ -0,0 +1,143 @@
+/*
+Hierarchical names for message loggers, with log levels set per name prefix.
+*/
+package messagelogger
+
+import (
+	"errors"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The Name type is a parameter of New() giving a message logger a dotted name,
+e.g. "senzing.g2engine.addRecord".
+Levels set with SetNameLogLevel() for a prefix of the name apply to the message logger.
+*/
+type Name string
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Log levels set with SetNameLogLevel(), by name prefix. Guarded by lock.
+var nameLogLevels = map[string]Level{}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return true if prefix is name or one of the dotted parents of name.
+func isNamePrefix(prefix string, name string) bool {
+	return name == prefix || strings.HasPrefix(name, prefix+".")
+}
+
+/*
+Return the log level of the most specific prefix of name set with SetNameLogLevel().
+The caller must hold lock.
+*/
+func nameLogLevel(name string) (Level, bool) {
+	if len(name) == 0 {
+		return LevelInfo, false
+	}
+	result := LevelInfo
+	resultPrefix := ""
+	isFound := false
+	for prefix, level := range nameLogLevels {
+		if isNamePrefix(prefix, name) && len(prefix) > len(resultPrefix) {
+			result = level
+			resultPrefix = prefix
+			isFound = true
+		}
+	}
+	return result, isFound
+}
+
+// Return the name of a message logger, or "" if it has none.
+func observerName(messageLogger MessageLoggerInterface) string {
+	if messageLoggerDefault, ok := messageLogger.(*MessageLoggerDefault); ok {
+		return messageLoggerDefault.Name
+	}
+	return ""
+}
+
+// Return the log level a message logger was created with, or INFO if it is not a MessageLoggerDefault.
+func observerNewLogLevel(messageLogger MessageLoggerInterface) Level {
+	if messageLoggerDefault, ok := messageLogger.(*MessageLoggerDefault); ok {
+		return messageLoggerDefault.newLogLevel
+	}
+	return LevelInfo
+}
+
+/*
+Set the level of each message logger named by prefix to its most specific name level.
+If it has none, set it to the system log level or, if that is not set, the level it was created with.
+The caller must hold lock.
+*/
+func applyNameLogLevels(prefix string) {
+	for _, messageLogger := range messageLoggerObservers {
+		name := observerName(messageLogger)
+		if len(name) == 0 || !isNamePrefix(prefix, name) {
+			continue
+		}
+		if level, ok := nameLogLevel(name); ok {
+			messageLogger.SetLogLevel(level)
+		} else if isSystemLogLevelSet {
+			messageLogger.SetLogLevel(systemLogLevel)
+		} else {
+			messageLogger.SetLogLevel(observerNewLogLevel(messageLogger))
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The ClearNameLogLevel function removes the log level set by SetNameLogLevel() for a name prefix.
+Message loggers named by the prefix return to the level of the next most specific prefix,
+or to the system log level, or, if SetLogLevel() has not been called, to the level given to New().
+*/
+func ClearNameLogLevel(prefix string) {
+	lock.Lock()
+	defer lock.Unlock()
+	delete(nameLogLevels, prefix)
+	applyNameLogLevels(prefix)
+}
+
+/*
+The GetNameLogLevel function returns the log level that SetNameLogLevel() sets for a message logger name,
+from the most specific prefix of the name.
+The boolean is false if no prefix of the name has a level.
+*/
+func GetNameLogLevel(name string) (Level, bool) {
+	lock.Lock()
+	defer lock.Unlock()
+	return nameLogLevel(name)
+}
+
+/*
+The SetNameLogLevel function sets the log level of every message logger whose Name is prefix
+or begins with prefix and a dot, now and when it is created.
+When several prefixes match a name, the most specific wins.
+SetLogLevel() does not change message loggers that have a name level.
+Example:
+
+	messagelogger.SetLogLevel(messagelogger.LevelWarn)
+	messagelogger.SetNameLogLevel("senzing.g2engine", messagelogger.LevelTrace)
+	engineLogger, _ := messagelogger.New(messagelogger.Name("senzing.g2engine.addRecord"))
+*/
+func SetNameLogLevel(prefix string, level Level) error {
+	if len(prefix) == 0 {
+		return errors.New("name prefix must not be empty")
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	nameLogLevels[prefix] = level
+	applyNameLogLevels(prefix)
+	return nil
+}
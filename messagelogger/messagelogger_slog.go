@@ -0,0 +1,181 @@
+/*
+The SlogHandler implementation is a log/slog Handler (https://pkg.go.dev/log/slog#Handler)
+that routes records through a MessageLoggerInterface.
+*/
+package messagelogger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/senzing/go-logging/logger"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The SlogHandler type routes slog records through a MessageLoggerInterface.
+Each record is logged using MessageNumber.
+The record's level is mapped to a logger.Level, at most ERROR so that a record never ends the program,
+and its message and attributes become "details",
+keeping the type of each attribute value.
+Attributes holding an error are passed as errors so they appear in the "errors" field.
+With a *MessageLoggerDefault, the record's time is used for the "date" and "time" fields
+and the caller of the slog.Logger method for the "location" field.
+Other message loggers receive the level as a logger.Level detail.
+*/
+type SlogHandler struct {
+	MessageLogger MessageLoggerInterface // Logger that receives the records.
+	MessageNumber int                    // Message number used for every record.
+	attrs         []slog.Attr            // Attributes added by WithAttrs(), with group prefixes applied.
+	groupPrefix   string                 // Prefix added by WithGroup() to the keys of later attributes.
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+The NewSlogHandler function creates a slog.Handler that logs every record
+through messageLogger using messageNumber.
+Example:
+
+	slogLogger := slog.New(messagelogger.NewSlogHandler(messageLogger, 2000))
+*/
+func NewSlogHandler(messageLogger MessageLoggerInterface, messageNumber int) *SlogHandler {
+	return &SlogHandler{
+		MessageLogger: messageLogger,
+		MessageNumber: messageNumber,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return true if the message logger will log a message at the given level.
+func isMessageLoggerLevelEnabled(messageLogger MessageLoggerInterface, level logger.Level) bool {
+	switch level {
+	case logger.LevelTrace:
+		return messageLogger.IsTrace()
+	case logger.LevelDebug:
+		return messageLogger.IsDebug()
+	case logger.LevelWarn:
+		return messageLogger.IsWarn()
+	case logger.LevelError:
+		return messageLogger.IsError()
+	case logger.LevelFatal:
+		return messageLogger.IsFatal()
+	case logger.LevelPanic:
+		return messageLogger.IsPanic()
+	default:
+		return messageLogger.IsInfo()
+	}
+}
+
+// Return the value of a slog attribute as a detail value of the same type.
+func slogValue(value slog.Value) interface{} {
+	switch value.Kind() {
+	case slog.KindDuration, slog.KindTime:
+		return value.String()
+	default:
+		return value.Any()
+	}
+}
+
+// Add an attribute, and the members of a group attribute, to details.
+func addSlogAttr(prefix string, attr slog.Attr, namedDetails map[string]interface{}, errorDetails []interface{}) []interface{} {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return errorDetails
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if len(attr.Key) > 0 {
+			groupPrefix = prefix + attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			errorDetails = addSlogAttr(groupPrefix, groupAttr, namedDetails, errorDetails)
+		}
+		return errorDetails
+	}
+	if err, ok := attr.Value.Any().(error); ok {
+		return append(errorDetails, err)
+	}
+	namedDetails[prefix+attr.Key] = slogValue(attr.Value)
+	return errorDetails
+}
+
+/*
+Return the logger.Level of a slog record level, at most ERROR.
+Records at higher levels are not FATAL or PANIC, as a slog.Handler must not exit or panic.
+*/
+func slogRecordLevel(level slog.Level) logger.Level {
+	result := logger.SlogLevelToLevel(level)
+	if result > logger.LevelError {
+		return logger.LevelError
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Enabled method returns true if the message logger logs messages at the record level.
+func (handler *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return isMessageLoggerLevelEnabled(handler.MessageLogger, slogRecordLevel(level))
+}
+
+// The Handle method logs the record using the message logger.
+func (handler *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	namedDetails := map[string]interface{}{}
+	if len(record.Message) > 0 {
+		namedDetails[slog.MessageKey] = record.Message
+	}
+	var errorDetails []interface{}
+	for _, attr := range handler.attrs {
+		errorDetails = addSlogAttr("", attr, namedDetails, errorDetails)
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		errorDetails = addSlogAttr(handler.groupPrefix, attr, namedDetails, errorDetails)
+		return true
+	})
+
+	details := []interface{}{namedDetails}
+	details = append(details, errorDetails...)
+	level := slogRecordLevel(record.Level)
+	if messageLogger, ok := handler.MessageLogger.(*MessageLoggerDefault); ok {
+		return messageLogger.logRecord(ctx, handler.MessageNumber, level, record.Time, record.PC, details...)
+	}
+	details = append(details, level)
+	if messageLogger, ok := handler.MessageLogger.(MessageLoggerContextInterface); ok {
+		return messageLogger.LogContext(ctx, handler.MessageNumber, details...)
+	}
+	return handler.MessageLogger.Log(handler.MessageNumber, details...)
+}
+
+// The WithAttrs method returns a handler that adds attrs to every record.
+func (handler *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	result := *handler
+	result.attrs = make([]slog.Attr, 0, len(handler.attrs)+len(attrs))
+	result.attrs = append(result.attrs, handler.attrs...)
+	for _, attr := range attrs {
+		attr.Key = handler.groupPrefix + attr.Key
+		result.attrs = append(result.attrs, attr)
+	}
+	return &result
+}
+
+// The WithGroup method returns a handler that qualifies later attribute keys with name.
+func (handler *SlogHandler) WithGroup(name string) slog.Handler {
+	if len(strings.TrimSpace(name)) == 0 {
+		return handler
+	}
+	result := *handler
+	result.groupPrefix = handler.groupPrefix + name + "."
+	return &result
+}
@@ -1,16 +1,30 @@
 package messagelogger
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/senzing/go-logging/logger"
 	"github.com/senzing/go-logging/messagedate"
+	"github.com/senzing/go-logging/messagedetails"
 	"github.com/senzing/go-logging/messageformat"
 	"github.com/senzing/go-logging/messagelocation"
+	"github.com/senzing/go-logging/messagestatus"
 	"github.com/senzing/go-logging/messagetext"
 	"github.com/senzing/go-logging/messagetime"
+	"github.com/senzing/go-logging/messagetrace"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -318,6 +332,14 @@ func TestMessageLoggerNewLogMessageWithObjects(test *testing.T) {
 
 // -- Test Log() method using New(...) ----------------------------------------
 
+func TestMessageLoggerNewLogMessageWithNamedDetails(test *testing.T) {
+	testObject, err := New(messageText, messageFormat)
+	testError(test, testObject, err)
+	actual, err := testObject.Message(2001, messagedetails.Pair("name", "Bob"), "Jane", messagedetails.Pairs("recordId", 1001))
+	testError(test, testObject, err)
+	assert.Equal(test, `{"level":"INFO","id":"2001","text":"Bob knows Jane","details":{"2":"Jane","name":"Bob","recordId":1001}}`, actual)
+}
+
 func TestMessageLoggerNewLogMessageWithWarningLevel(test *testing.T) {
 	testObject, err := New(logger.LevelWarn)
 	testError(test, testObject, err)
@@ -361,6 +383,813 @@ func TestMessageLoggerNewBadInterfaces(test *testing.T) {
 	}
 }
 
+func TestMessageLoggerNewWithOutput(test *testing.T) {
+	var buffer1 bytes.Buffer
+	var buffer2 bytes.Buffer
+	testObject1, err := New(&buffer1, logger.Prefix("audit: "), logger.Flags(0))
+	testError(test, testObject1, err)
+	testObject2, err := New(&buffer2, logger.Flags(0))
+	testError(test, testObject2, err)
+	testObject1.Log(2001, "Bob")
+	testObject2.Log(2002, "Jane")
+	assert.Equal(test, "audit: INFO 2001: map[1:Bob]\n", buffer1.String())
+	assert.Equal(test, "INFO 2002: map[1:Jane]\n", buffer2.String())
+}
+
+func TestMessageLoggerNewWithOutputAndLogger(test *testing.T) {
+	var buffer bytes.Buffer
+	_, err := New(&buffer, logger.New())
+	assert.Error(test, err)
+}
+
+func TestMessageLoggerNewWithMessageSinks(test *testing.T) {
+	var consoleBuffer bytes.Buffer
+	var fileBuffer bytes.Buffer
+	consoleSink := &MessageSink{
+		Logger:        logger.NewWithOutput(&consoleBuffer, "", 0).SetLogLevel(logger.LevelInfo),
+		MessageFormat: &messageformat.MessageFormatDefault{},
+	}
+	fileSink := &MessageSink{
+		Logger: logger.NewWithOutput(&fileBuffer, "", 0).SetLogLevel(logger.LevelTrace),
+	}
+	testObject, err := NewSenzingLogger(9999, idMessages, consoleSink, fileSink, messageDate, messageTime, messageLocation, logger.LevelTrace)
+	testError(test, testObject, err)
+	testObject.Log(1, "A")
+	testObject.Log(2001, "Bob", "Jane")
+	assert.Equal(test, "INFO senzing-99992001: Bob knows Jane map[1:Bob 2:Jane]\n", consoleBuffer.String())
+	expectedFile := `{"date":"2000-01-01","time":"00:00:00.000000000","level":"TRACE","id":"senzing-99990001","location":"In AFunction() at somewhere.go:1234","details":{"1":"A"}}` + "\n" +
+		`{"date":"2000-01-01","time":"00:00:00.000000000","level":"INFO","id":"senzing-99992001","text":"Bob knows Jane","location":"In AFunction() at somewhere.go:1234","details":{"1":"Bob","2":"Jane"}}` + "\n"
+	assert.Equal(test, expectedFile, fileBuffer.String())
+	for _, line := range strings.Split(strings.TrimSpace(fileBuffer.String()), "\n") {
+		assert.NoError(test, messageformat.ValidateSenzing(line), line)
+	}
+}
+
+func TestMessageLoggerNewWithBinaryFormat(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "test.cbor")
+	file, err := os.Create(filename)
+	testError(test, nil, err)
+	var sinkBuffer bytes.Buffer
+	fileLogger, err := New(file, logger.Flags(0), &messageformat.MessageFormatCbor{})
+	testError(test, fileLogger, err)
+	sinkLogger, err := New(NewWriterSink(&sinkBuffer, &messageformat.MessageFormatMessagePack{}))
+	testError(test, sinkLogger, err)
+	for _, testObject := range []MessageLoggerInterface{fileLogger, sinkLogger} {
+		testObject.Log(2001, "Bob")
+		testObject.Log(2002, 10)
+		testObject.Log(2003)
+	}
+	testError(test, fileLogger, file.Close())
+
+	expected := `{"level":"INFO","id":"2001","details":{"1":"Bob"}}` + "\n" +
+		`{"level":"INFO","id":"2002","details":{"1":10}}` + "\n" +
+		`{"level":"INFO","id":"2003"}` + "\n"
+	data, err := os.ReadFile(filename)
+	testError(test, fileLogger, err)
+	assert.Equal(test, 1, bytes.Count(data, []byte("\n")), "only the CBOR integer 10")
+	var actual strings.Builder
+	testError(test, fileLogger, messageformat.CborStreamAsJson(bytes.NewReader(data), &actual))
+	assert.Equal(test, expected, actual.String())
+	actual.Reset()
+	testError(test, fileLogger, messageformat.MessagePackStreamAsJson(&sinkBuffer, &actual))
+	assert.Equal(test, expected, actual.String())
+}
+
+func TestMessageLoggerNewWithConsoleFormat(test *testing.T) {
+	var buffer bytes.Buffer
+	consoleFormat := &messageformat.MessageFormatConsole{}
+	testObject, err := New(&buffer, logger.Flags(0), consoleFormat)
+	testError(test, testObject, err)
+	assert.Same(test, &buffer, consoleFormat.Output)
+
+	var sinkBuffer bytes.Buffer
+	sinkFormat := &messageformat.MessageFormatConsole{}
+	testObject, err = New(NewWriterSink(&sinkBuffer, sinkFormat))
+	testError(test, testObject, err)
+	assert.Same(test, &sinkBuffer, sinkFormat.Output)
+
+	testError(test, testObject, testObject.Log(2001, "Bob"))
+	assert.Equal(test, "INFO  2001:\n    details:\n        1: Bob\n", sinkBuffer.String())
+}
+
+func TestMessageLoggerNewWithBinaryFormatPrefixAndFlags(test *testing.T) {
+	var buffer bytes.Buffer
+	_, err := New(&buffer, &messageformat.MessageFormatCbor{}, logger.Flags(log.LstdFlags))
+	assert.EqualError(test, err, "logger.Prefix and logger.Flags must be empty for a binary message format")
+	_, err = New(&buffer, &messageformat.MessageFormatMessagePack{}, logger.Prefix("app: "))
+	assert.EqualError(test, err, "logger.Prefix and logger.Flags must be empty for a binary message format")
+
+	// Without logger.Prefix and logger.Flags, neither is written.
+
+	testObject, err := New(&buffer, &messageformat.MessageFormatCbor{})
+	testError(test, testObject, err)
+	testObject.Log(2001, "Bob")
+	var actual strings.Builder
+	testError(test, testObject, messageformat.CborStreamAsJson(&buffer, &actual))
+	assert.Equal(test, `{"level":"INFO","id":"2001","details":{"1":"Bob"}}`+"\n", actual.String())
+}
+
+func TestMessageLoggerNewWithMessageSinksLevel(test *testing.T) {
+	var buffer bytes.Buffer
+	messageSink := &MessageSink{
+		Logger: logger.NewWithOutput(&buffer, "", 0).SetLogLevel(logger.LevelTrace),
+	}
+	testObject, err := New(messageSink, logger.LevelWarn)
+	testError(test, testObject, err)
+	testObject.Log(1, logger.LevelInfo)
+	testObject.Log(2, logger.LevelWarn)
+	assert.Equal(test, "WARN 2: map[1:3]\n", buffer.String())
+}
+
+func TestMessageLoggerNewWithMessageSinksPanic(test *testing.T) {
+	var buffer1 bytes.Buffer
+	var buffer2 bytes.Buffer
+	messageSink1 := &MessageSink{
+		Logger: logger.NewWithOutput(&buffer1, "", 0),
+	}
+	messageSink2 := &MessageSink{
+		Logger: logger.NewWithOutput(&buffer2, "", 0),
+	}
+	testObject, err := New(messageSink1, messageSink2)
+	testError(test, testObject, err)
+	assert.PanicsWithValue(test, "PANIC 1: map[1:6]", func() { testObject.Log(1, logger.LevelPanic) })
+	assert.Equal(test, "PANIC 1: map[1:6]\n", buffer1.String())
+	assert.Equal(test, "PANIC 1: map[1:6]\n", buffer2.String())
+}
+
+func TestMessageLoggerNewWithMessageSinksFatal(test *testing.T) {
+	exitCodes := []int{}
+	osExit = func(code int) { exitCodes = append(exitCodes, code) }
+	test.Cleanup(func() { osExit = os.Exit })
+	var buffer bytes.Buffer
+	handler := slog.NewTextHandler(&buffer, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+	messageSink1 := &MessageSink{Logger: logger.NewSlog(handler)}
+	messageSink2 := &MessageSink{Logger: logger.NewSlog(handler)}
+	testObject, err := New(messageSink1, messageSink2)
+	testError(test, testObject, err)
+	testError(test, testObject, testObject.Log(1, logger.LevelFatal))
+	assert.Equal(test, []int{1}, exitCodes)
+	assert.Equal(test, "level=ERROR+4 msg=\"FATAL 1: map[1:5]\"\nlevel=ERROR+4 msg=\"FATAL 1: map[1:5]\"\n", buffer.String())
+}
+
+func TestMessageLoggerNewWithMessageSinksExternalLogger(test *testing.T) {
+	exitCodes := []int{}
+	osExit = func(code int) { exitCodes = append(exitCodes, code) }
+	test.Cleanup(func() { osExit = os.Exit })
+	var buffer bytes.Buffer
+	// Only the methods of logger.LoggerInterface, as in a logger implemented outside this module.
+	externalLogger := struct{ logger.LoggerInterface }{logger.NewWithOutput(&buffer, "", 0)}
+	testObject, err := New(&MessageSink{Logger: externalLogger})
+	testError(test, testObject, err)
+	testError(test, testObject, testObject.Log(1, logger.LevelInfo))
+	testError(test, testObject, testObject.Log(2, logger.LevelFatal))
+	assert.Equal(test, []int{1}, exitCodes)
+	assert.Equal(test, "INFO 1: map[1:2]\nFATAL 2: map[1:5]\n", buffer.String())
+}
+
+func TestMessageLoggerNewWithSlogHandler(test *testing.T) {
+	var buffer bytes.Buffer
+	handler := slog.NewJSONHandler(&buffer, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+	testObject, err := New(handler, messageText)
+	testError(test, testObject, err)
+	testObject.Log(2001, "Bob", "Jane")
+	assert.Equal(test, `{"level":"INFO","msg":"INFO 2001: Bob knows Jane map[1:Bob 2:Jane]"}`+"\n", buffer.String())
+}
+
+// -- Test Error() method -----------------------------------------------------
+
+func TestMessageLoggerNewError(test *testing.T) {
+	cause := errors.New("test error")
+	testObject, err := New(messageText, messageFormat)
+	testError(test, testObject, err)
+	expected, err := testObject.Message(2001, "Bob", "Jane", cause)
+	testError(test, testObject, err)
+	actual := testObject.Error(2001, "Bob", "Jane", cause)
+	assert.Equal(test, expected, actual.Error())
+	assert.ErrorIs(test, actual, cause)
+	var messageError *MessageError
+	if assert.ErrorAs(test, actual, &messageError) {
+		assert.Equal(test, 2001, messageError.MessageNumber)
+		assert.Equal(test, "2001", messageError.Id)
+		assert.Equal(test, logger.LevelInfo, messageError.Level)
+		assert.Equal(test, []interface{}{"Bob", "Jane", cause}, messageError.Details)
+	}
+}
+
+func TestMessageLoggerNewSenzingApiLoggerErrorStatus(test *testing.T) {
+	senzingError := errors.New("9995E|Mock retryable error")
+	testObject, err := NewSenzingApiLogger(9999, idMessages, nil)
+	testError(test, testObject, err)
+	actual := testObject.Error(4001, "Bob", "Jane", senzingError)
+	var messageError *MessageError
+	if assert.ErrorAs(test, actual, &messageError) {
+		assert.Equal(test, "senzing-99994001", messageError.Id)
+		assert.Equal(test, messagestatus.ErrorRetryable, messageError.Status)
+		assert.Equal(test, logger.LevelError, messageError.Level)
+	}
+	assert.ErrorIs(test, actual, senzingError)
+}
+
+// -- Test XxxxContext() methods ----------------------------------------------
+
+type testContextKey string
+
+func TestMessageLoggerNewContext(test *testing.T) {
+	requestIdKey := testContextKey("requestId")
+	test.Cleanup(RegisterContextExtractor(ContextValue(requestIdKey, "requestId")))
+	ctx := context.WithValue(context.Background(), requestIdKey, "abc-123")
+
+	var buffer bytes.Buffer
+	testObject, err := New(&buffer, logger.Flags(0), messageText, messageFormat)
+	testError(test, testObject, err)
+
+	expected := `{"level":"INFO","id":"2001","text":"Bob knows Jane","details":{"1":"Bob","2":"Jane","requestId":"abc-123"}}`
+	actual, err := testObject.(MessageLoggerContextInterface).MessageContext(ctx, 2001, "Bob", "Jane")
+	testError(test, testObject, err)
+	assert.Equal(test, expected, actual)
+
+	err = testObject.(MessageLoggerContextInterface).LogContext(ctx, 2001, "Bob", "Jane")
+	testError(test, testObject, err)
+	assert.Equal(test, expected+"\n", buffer.String())
+
+	assert.Equal(test, expected, testObject.(MessageLoggerContextInterface).ErrorContext(ctx, 2001, "Bob", "Jane").Error())
+
+	actual, err = testObject.(MessageLoggerContextInterface).MessageContext(context.Background(), 2001, "Bob", "Jane")
+	testError(test, testObject, err)
+	assert.NotContains(test, actual, "requestId")
+}
+
+func TestRegisterContextExtractorUnregister(test *testing.T) {
+	requestIdKey := testContextKey("requestId")
+	ctx := context.WithValue(context.Background(), requestIdKey, "abc-123")
+	testObject, err := New(messageFormat)
+	testError(test, testObject, err)
+
+	unregister := RegisterContextExtractor(ContextValue(requestIdKey, "requestId"))
+	actual, err := testObject.(MessageLoggerContextInterface).MessageContext(ctx, 2001)
+	testError(test, testObject, err)
+	assert.Contains(test, actual, `"requestId":"abc-123"`)
+
+	unregister()
+	unregister()
+	actual, err = testObject.(MessageLoggerContextInterface).MessageContext(ctx, 2001)
+	testError(test, testObject, err)
+	assert.NotContains(test, actual, "requestId")
+}
+
+// -- Test trace context ------------------------------------------------------
+
+func TestMessageLoggerNewTrace(test *testing.T) {
+	traceContext := messagetrace.TraceContext{TraceId: "4bf92f3577b34da6a3ce929d0e0e4736", SpanId: "00f067aa0ba902b7"}
+	ctx := messagetrace.NewContext(context.Background(), traceContext)
+
+	var buffer bytes.Buffer
+	testObject, err := New(&buffer, logger.Flags(0), messageText, messageFormat)
+	testError(test, testObject, err)
+
+	expected := `{"level":"INFO","id":"2001","text":"Bob knows Jane","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7","details":{"1":"Bob","2":"Jane"}}`
+	err = testObject.(MessageLoggerContextInterface).LogContext(ctx, 2001, "Bob", "Jane")
+	testError(test, testObject, err)
+	assert.Equal(test, expected+"\n", buffer.String())
+	assert.NoError(test, messageformat.ValidateSenzing(strings.TrimSpace(buffer.String())))
+
+	// A "traceparent" header in the details.
+
+	actual, err := testObject.Message(2001, "Bob", "Jane", messagetrace.TraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+	testError(test, testObject, err)
+	assert.Equal(test, expected, actual)
+
+	// A malformed "traceparent" header is kept in the details.
+
+	actual, err = testObject.Message(2001, "Bob", "Jane", messagetrace.TraceParent("00-bad"))
+	testError(test, testObject, err)
+	assert.Equal(test, `{"level":"INFO","id":"2001","text":"Bob knows Jane","details":{"1":"Bob","2":"Jane","3":"00-bad"}}`, actual)
+
+	// Formats without trace fields are unchanged.
+
+	testObject, err = New(messageText)
+	testError(test, testObject, err)
+	actual, err = testObject.(MessageLoggerContextInterface).MessageContext(ctx, 2001, "Bob", "Jane")
+	testError(test, testObject, err)
+	assert.NotContains(test, actual, traceContext.TraceId)
+
+	// The message logger from new() ignores trace context.
+
+	testObject, err = new(messageText, messageFormat)
+	testError(test, testObject, err)
+	actual, err = testObject.(MessageLoggerContextInterface).MessageContext(ctx, 2001, "Bob", "Jane")
+	testError(test, testObject, err)
+	assert.Equal(test, `{"level":"INFO","id":"2001","text":"Bob knows Jane"}`, actual)
+}
+
+// -- Test With() method ------------------------------------------------------
+
+func TestMessageLoggerNewWith(test *testing.T) {
+	var buffer bytes.Buffer
+	parent, err := New(&buffer, logger.Flags(0), messageText, messageFormat)
+	testError(test, parent, err)
+	testObject := parent.(MessageLoggerWithInterface).With(map[string]string{"dataSource": "CUSTOMERS"}, "job-7")
+
+	err = testObject.Log(2001, "Bob", "Jane")
+	testError(test, testObject, err)
+	err = testObject.(MessageLoggerWithInterface).With("record-1001").Log(2001, "Mary", "Joe")
+	testError(test, testObject, err)
+	expected := `{"level":"INFO","id":"2001","text":"Bob knows Jane","details":{"2":"job-7","3":"Bob","4":"Jane","dataSource":"CUSTOMERS"}}` + "\n" +
+		`{"level":"INFO","id":"2001","text":"Mary knows Joe","details":{"2":"job-7","3":"record-1001","4":"Mary","5":"Joe","dataSource":"CUSTOMERS"}}` + "\n"
+	assert.Equal(test, expected, buffer.String())
+
+	actual, err := parent.Message(2001, "Bob", "Jane")
+	testError(test, parent, err)
+	assert.NotContains(test, actual, "CUSTOMERS")
+
+	parent.SetLogLevel(LevelWarn)
+	assert.False(test, testObject.IsInfo())
+	testObject.SetLogLevel(LevelInfo)
+	assert.True(test, parent.IsInfo())
+}
+
+// -- Test asynchronous logging -----------------------------------------------
+
+// A writer that blocks its first write until released.
+type testBlockingWriter struct {
+	buffer   bytes.Buffer
+	blocked  chan struct{}
+	released chan struct{}
+}
+
+func (writer *testBlockingWriter) Write(data []byte) (int, error) {
+	if writer.blocked != nil {
+		close(writer.blocked)
+		writer.blocked = nil
+		<-writer.released
+	}
+	return writer.buffer.Write(data)
+}
+
+func testAsyncOverflow(test *testing.T, overflowPolicy OverflowPolicy, expected string) {
+	writer := &testBlockingWriter{
+		blocked:  make(chan struct{}),
+		released: make(chan struct{}),
+	}
+	testObject, err := New(writer, logger.Flags(0), &Async{QueueSize: 1, OverflowPolicy: overflowPolicy})
+	testError(test, testObject, err)
+	blocked := writer.blocked
+	testObject.Log(1, "A")
+	<-blocked
+	testObject.Log(2, "B")
+	testObject.Log(3, "C")
+	close(writer.released)
+	asyncLogger := testObject.(MessageLoggerAsyncInterface)
+	testError(test, testObject, asyncLogger.Close())
+	assert.Equal(test, expected, writer.buffer.String())
+	assert.Equal(test, uint64(1), asyncLogger.Dropped())
+}
+
+func TestMessageLoggerNewAsync(test *testing.T) {
+	var buffer bytes.Buffer
+	testObject, err := New(&buffer, logger.Flags(0), messageText, &Async{})
+	testError(test, testObject, err)
+	testObject.Log(2001, "Bob", "Jane")
+	testObject.(MessageLoggerWithInterface).With("job-7").Log(2001, "Mary", "Joe")
+	asyncLogger := testObject.(MessageLoggerAsyncInterface)
+	testError(test, testObject, asyncLogger.Flush())
+	assert.Equal(test, "INFO 2001: Bob knows Jane map[1:Bob 2:Jane]\nINFO 2001: Mary knows Joe map[1:job-7 2:Mary 3:Joe]\n", buffer.String())
+	assert.Equal(test, uint64(0), asyncLogger.Dropped())
+
+	testError(test, testObject, asyncLogger.Close())
+	testObject.Log(2001, "Jane", "Bob")
+	assert.Contains(test, buffer.String(), "Jane knows Bob")
+}
+
+func TestMessageLoggerNewAsyncDropNewest(test *testing.T) {
+	testAsyncOverflow(test, OverflowDropNewest, "INFO 1: map[1:A]\nINFO 2: map[1:B]\n")
+}
+
+func TestMessageLoggerNewAsyncDropOldest(test *testing.T) {
+	testAsyncOverflow(test, OverflowDropOldest, "INFO 1: map[1:A]\nINFO 3: map[1:C]\n")
+}
+
+func TestMessageLoggerNewAsyncPanic(test *testing.T) {
+	var buffer bytes.Buffer
+	testObject, err := New(&buffer, logger.Flags(0), &Async{})
+	testError(test, testObject, err)
+	testObject.Log(1, "A")
+	assert.Panics(test, func() { testObject.Log(2, logger.LevelPanic) })
+	assert.True(test, strings.HasPrefix(buffer.String(), "INFO 1: map[1:A]\nPANIC 2: map[1:6]\n"))
+	testError(test, testObject, testObject.(MessageLoggerAsyncInterface).Close())
+}
+
+// -- Test SlogHandler --------------------------------------------------------
+
+func TestSlogHandler(test *testing.T) {
+	var buffer bytes.Buffer
+	messageLogger, err := New(&buffer, logger.Flags(0), messageFormat)
+	testError(test, messageLogger, err)
+	slogLogger := slog.New(NewSlogHandler(messageLogger, 2000))
+	slogLogger.Debug("not logged")
+	slogLogger.With("job", 7).WithGroup("record").Warn("A warning", "id", "1001", "flag", "true", "active", true, "error", errors.New("test error"))
+	assert.Equal(test, `{"level":"WARN","id":"2000","errors":[{"text":"test error"}],"details":{"job":7,"msg":"A warning","record.active":true,"record.flag":"true","record.id":"1001"}}`+"\n", buffer.String())
+}
+
+func TestSlogHandlerExternalMessageLogger(test *testing.T) {
+	var buffer bytes.Buffer
+	messageLogger, err := New(&buffer, logger.Flags(0), messageFormat)
+	testError(test, messageLogger, err)
+	// Only the methods of MessageLoggerInterface, as in a message logger implemented outside this package.
+	externalLogger := struct{ MessageLoggerInterface }{messageLogger}
+	slog.New(NewSlogHandler(externalLogger, 2000)).Warn("A warning", "id", "1001")
+	assert.Equal(test, `{"level":"WARN","id":"2000","details":{"2":3,"id":"1001","msg":"A warning"}}`+"\n", buffer.String())
+}
+
+func TestSlogHandlerLocation(test *testing.T) {
+	var buffer bytes.Buffer
+	messageLogger, err := New(&buffer, logger.Flags(0), messageFormat, &messagelocation.MessageLocationDefault{CallerSkip: 3})
+	testError(test, messageLogger, err)
+	slog.New(NewSlogHandler(messageLogger, 2000)).Info("hello")
+	assert.Contains(test, buffer.String(), `"location":"In TestSlogHandlerLocation() at messagelogger_test.go:`)
+}
+
+func TestSlogHandlerTime(test *testing.T) {
+	var buffer bytes.Buffer
+	messageLogger, err := New(&buffer, logger.Flags(0), messageFormat, &messagedate.MessageDateSenzing{}, &messagetime.MessageTimeSenzing{})
+	testError(test, messageLogger, err)
+	testObject := NewSlogHandler(messageLogger, 2000)
+	record := slog.NewRecord(time.Date(2000, time.January, 2, 3, 4, 5, 6, time.UTC), slog.LevelError, "hello", 0)
+	testError(test, messageLogger, testObject.Handle(context.Background(), record))
+	assert.Equal(test, `{"date":"2000-01-02","time":"03:04:05.000000006","level":"ERROR","id":"2000","details":{"msg":"hello"}}`+"\n", buffer.String())
+}
+
+func TestSlogHandlerHighLevel(test *testing.T) {
+	test.Cleanup(func() { osExit = os.Exit })
+	osExit = func(code int) { assert.Fail(test, "exited") }
+	var buffer bytes.Buffer
+	var sinkBuffer bytes.Buffer
+	bufferLogger, err := New(&buffer, logger.Flags(0), messageFormat)
+	testError(test, bufferLogger, err)
+	sinkLogger, err := New(NewWriterSink(&sinkBuffer, messageFormat))
+	testError(test, sinkLogger, err)
+	for _, messageLogger := range []MessageLoggerInterface{bufferLogger, sinkLogger} {
+		slogLogger := slog.New(NewSlogHandler(messageLogger, 2000))
+		assert.NotPanics(test, func() {
+			slogLogger.Log(context.Background(), slog.Level(12), "very high")
+			slogLogger.Log(context.Background(), logger.SlogLevelPanic, "panic level")
+		})
+	}
+	expected := `{"level":"ERROR","id":"2000","details":{"msg":"very high"}}` + "\n" +
+		`{"level":"ERROR","id":"2000","details":{"msg":"panic level"}}` + "\n"
+	assert.Equal(test, expected, buffer.String())
+	assert.Equal(test, expected, sinkBuffer.String())
+}
+
+func TestSlogHandlerEnabled(test *testing.T) {
+	messageLogger, err := New(logger.LevelWarn)
+	testError(test, messageLogger, err)
+	testObject := NewSlogHandler(messageLogger, 2000)
+	assert.False(test, testObject.Enabled(nil, slog.LevelInfo))
+	assert.True(test, testObject.Enabled(nil, slog.LevelWarn))
+}
+
+// -- Test runtime log level control ------------------------------------------
+
+// Restore the system log level when the test ends.
+func testRestoreSystemLogLevel(test *testing.T) {
+	lock.Lock()
+	isSet, level := isSystemLogLevelSet, systemLogLevel
+	lock.Unlock()
+	test.Cleanup(func() {
+		lock.Lock()
+		defer lock.Unlock()
+		isSystemLogLevelSet, systemLogLevel = isSet, level
+	})
+}
+
+func TestLevelHandler(test *testing.T) {
+	testRestoreSystemLogLevel(test)
+	loaderLogger, err := New()
+	testError(test, loaderLogger, err)
+	testObject := &LevelHandler{Loggers: map[string]MessageLoggerInterface{"loader": loaderLogger}}
+	for _, testCase := range []struct {
+		method   string
+		target   string
+		body     string
+		status   int
+		expected string
+	}{
+		{http.MethodGet, "/", "", http.StatusOK, `{"level":"INFO"}`},
+		{http.MethodPut, "/", `{"level":"debug"}`, http.StatusOK, `{"level":"DEBUG"}`},
+		{http.MethodGet, "/", "", http.StatusOK, `{"level":"DEBUG"}`},
+		{http.MethodPost, "/?level=WARN", "", http.StatusOK, `{"level":"WARN"}`},
+		{http.MethodPut, "/?logger=loader", "TRACE\n", http.StatusOK, `{"logger":"loader","level":"TRACE"}`},
+		{http.MethodGet, "/?logger=loader", "", http.StatusOK, `{"logger":"loader","level":"TRACE"}`},
+		{http.MethodGet, "/", "", http.StatusOK, `{"level":"WARN"}`},
+		{http.MethodPut, "/", "LOUD", http.StatusBadRequest, `{"error":"unknown log level name: LOUD"}`},
+		{http.MethodGet, "/?logger=missing", "", http.StatusNotFound, `{"error":"unknown logger: missing"}`},
+		{http.MethodDelete, "/", "", http.StatusMethodNotAllowed, `{"error":"unsupported method: DELETE"}`},
+	} {
+		recorder := httptest.NewRecorder()
+		testObject.ServeHTTP(recorder, httptest.NewRequest(testCase.method, testCase.target, strings.NewReader(testCase.body)))
+		name := testCase.method + " " + testCase.target
+		assert.Equal(test, testCase.status, recorder.Code, name)
+		assert.Equal(test, "application/json", recorder.Header().Get("Content-Type"), name)
+		assert.Equal(test, testCase.expected+"\n", recorder.Body.String(), name)
+	}
+}
+
+// Run with -race: changing levels must not race with logging.
+func TestLevelHandlerWhileLogging(test *testing.T) {
+	testRestoreSystemLogLevel(test)
+	defaultLogger, err := New(io.Discard, logger.Flags(0))
+	testError(test, defaultLogger, err)
+	slogLogger, err := New(slog.NewTextHandler(io.Discard, nil))
+	testError(test, slogLogger, err)
+	testObject := &LevelHandler{Loggers: map[string]MessageLoggerInterface{"default": defaultLogger, "slog": slogLogger}}
+
+	done := make(chan struct{})
+	var started sync.WaitGroup
+	var waitGroup sync.WaitGroup
+	for _, messageLogger := range []MessageLoggerInterface{defaultLogger, slogLogger} {
+		started.Add(1)
+		waitGroup.Add(1)
+		go func(messageLogger MessageLoggerInterface) {
+			defer waitGroup.Done()
+			started.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if messageLogger.IsDebug() {
+					_ = messageLogger.Log(1, "debug")
+				}
+				_ = messageLogger.Log(2001, "info")
+				_ = messageLogger.GetLogLevelAsString()
+			}
+		}(messageLogger)
+	}
+	started.Wait()
+	for index := 0; index < 100; index++ {
+		for _, target := range []string{"/", "/?logger=default", "/?logger=slog"} {
+			level := []string{"DEBUG", "INFO"}[index%2]
+			recorder := httptest.NewRecorder()
+			testObject.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, target, strings.NewReader(level)))
+			assert.Equal(test, http.StatusOK, recorder.Code, target)
+		}
+	}
+	close(done)
+	waitGroup.Wait()
+}
+
+func TestHandleLevelSignals(test *testing.T) {
+	testRestoreSystemLogLevel(test)
+	stop, err := HandleLevelSignals()
+	if err != nil {
+		test.Skip(err.Error())
+	}
+	defer stop()
+	testError(test, nil, SetLogLevel(LevelInfo))
+	process, err := os.FindProcess(os.Getpid())
+	testError(test, nil, err)
+	for _, testCase := range []struct {
+		signal   os.Signal
+		expected Level
+	}{
+		{moreVerboseSignal, LevelDebug},
+		{moreVerboseSignal, LevelTrace},
+		{moreVerboseSignal, LevelTrace},
+		{lessVerboseSignal, LevelDebug},
+		{lessVerboseSignal, LevelInfo},
+	} {
+		testError(test, nil, process.Signal(testCase.signal))
+		assert.Eventually(test, func() bool {
+			level, _ := GetLogLevel()
+			return level == testCase.expected
+		}, 5*time.Second, time.Millisecond, testCase.expected)
+	}
+	testError(test, nil, SetLogLevel(LevelPanic))
+	assert.Equal(test, LevelPanic, stepLogLevel(1))
+}
+
+func TestStepLogLevelConcurrent(test *testing.T) {
+	testRestoreSystemLogLevel(test)
+	testError(test, nil, SetLogLevel(LevelTrace))
+	var waitGroup sync.WaitGroup
+	for index := 0; index < int(LevelPanic-LevelTrace); index++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			stepLogLevel(1)
+		}()
+	}
+	waitGroup.Wait()
+	level, err := GetLogLevel()
+	testError(test, nil, err)
+	assert.Equal(test, LevelPanic, level)
+}
+
+// -- Test NewFromEnvironment() -----------------------------------------------
+
+// Log a message for the caller.
+func testLogWrapper(messageLogger MessageLoggerInterface) error {
+	return messageLogger.Log(2001, "Bob", "Jane")
+}
+
+func TestNewFromEnvironment(test *testing.T) {
+	testRestoreSystemLogLevel(test)
+	filename := filepath.Join(test.TempDir(), "test.log")
+	test.Setenv(EnvLogLevel, "debug")
+	test.Setenv(EnvLogFormat, "JSON")
+	test.Setenv(EnvLogOutput, filename)
+	test.Setenv(EnvLogLocation, "true")
+
+	testObject, err := NewFromEnvironment(messageText)
+	testError(test, testObject, err)
+	assert.True(test, testObject.IsDebug())
+	testError(test, testObject, testObject.Log(2001, "Bob", "Jane"))
+	test.Setenv(EnvLogCallerSkip, "1")
+	testObject, err = NewFromEnvironment(messageText)
+	testError(test, testObject, err)
+	testError(test, testObject, testLogWrapper(testObject))
+
+	data, err := os.ReadFile(filename)
+	testError(test, testObject, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if assert.Len(test, lines, 2) {
+		for _, line := range lines {
+			assert.Contains(test, line, `{"level":"INFO","id":"2001","text":"Bob knows Jane","location":"In TestNewFromEnvironment() at messagelogger_test.go:`)
+		}
+	}
+
+	// Parameters override the environment.
+
+	var buffer bytes.Buffer
+	testObject, err = NewFromEnvironment(messageText, logger.LevelInfo, &messageformat.MessageFormatDefault{}, &messagelocation.MessageLocationNull{}, &buffer, logger.Flags(0))
+	testError(test, testObject, err)
+	assert.False(test, testObject.IsDebug())
+	testError(test, testObject, testObject.Log(2001, "Bob", "Jane"))
+	assert.Equal(test, "INFO 2001: Bob knows Jane map[1:Bob 2:Jane]\n", buffer.String())
+	data, err = os.ReadFile(filename)
+	testError(test, testObject, err)
+	assert.Len(test, strings.Split(strings.TrimSpace(string(data)), "\n"), 2)
+}
+
+func TestNewFromEnvironmentErrors(test *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{EnvLogLevel, "LOUD", `invalid SENZING_TOOLS_LOG_LEVEL "LOUD": unknown log level name: LOUD`},
+		{EnvLogFormat, "xml", `invalid SENZING_TOOLS_LOG_FORMAT "xml": format must be default, json, logfmt, or senzing`},
+		{EnvLogOutput, filepath.Join(test.TempDir(), "missing", "test.log"), `invalid SENZING_TOOLS_LOG_OUTPUT`},
+		{EnvLogLocation, "sometimes", `invalid SENZING_TOOLS_LOG_LOCATION "sometimes"`},
+		{EnvLogCallerSkip, "-1", `invalid SENZING_TOOLS_LOG_CALLER_SKIP "-1": caller skip must not be negative`},
+		{EnvLogCallerSkip, "one", `invalid SENZING_TOOLS_LOG_CALLER_SKIP "one"`},
+		{EnvLogCallerSkip, "1", `invalid SENZING_TOOLS_LOG_CALLER_SKIP "1": caller skip requires SENZING_TOOLS_LOG_LOCATION=true`},
+	} {
+		test.Run(testCase.name+"-"+testCase.value, func(test *testing.T) {
+			test.Setenv(testCase.name, testCase.value)
+			testObject, err := NewFromEnvironment()
+			assert.Nil(test, testObject)
+			assert.ErrorContains(test, err, testCase.expected)
+		})
+	}
+}
+
+func TestNewFromEnvironmentClosesOutput(test *testing.T) {
+	var file *os.File
+	test.Cleanup(func() { openFile = os.OpenFile })
+	openFile = func(name string, flag int, perm os.FileMode) (*os.File, error) {
+		var err error
+		file, err = os.OpenFile(name, flag, perm)
+		return file, err
+	}
+	test.Setenv(EnvLogOutput, filepath.Join(test.TempDir(), "test.log"))
+
+	testObject, err := NewFromEnvironment(42)
+	assert.Nil(test, testObject)
+	assert.ErrorContains(test, err, "unsupported interfaces")
+	if assert.NotNil(test, file) {
+		_, err = file.WriteString("after New() failed")
+		assert.ErrorIs(test, err, os.ErrClosed)
+	}
+}
+
+// -- Test named loggers ------------------------------------------------------
+
+func TestSetNameLogLevel(test *testing.T) {
+	testRestoreSystemLogLevel(test)
+	test.Cleanup(func() {
+		ClearNameLogLevel("senzing")
+		ClearNameLogLevel("senzing.g2engine")
+	})
+	testError(test, nil, SetLogLevel(LevelWarn))
+	testError(test, nil, SetNameLogLevel("senzing", LevelDebug))
+	engineLogger, err := New(Name("senzing.g2engine.addRecord"))
+	testError(test, engineLogger, err)
+	configLogger, err := New(Name("senzing.g2config"))
+	testError(test, configLogger, err)
+	otherLogger, err := New(Name("senzingx"))
+	testError(test, otherLogger, err)
+	unnamedLogger, err := New()
+	testError(test, unnamedLogger, err)
+
+	testCases := []struct {
+		name                   string
+		action                 func()
+		expectedEngineLevel    Level
+		expectedConfigLevel    Level
+		expectedOtherLevel     Level
+		expectedUnnamedLevel   Level
+		expectedNameLevelFound bool
+	}{
+		{
+			name:                   "prefix-on-creation",
+			action:                 func() {},
+			expectedEngineLevel:    LevelDebug,
+			expectedConfigLevel:    LevelDebug,
+			expectedOtherLevel:     LevelWarn,
+			expectedUnnamedLevel:   LevelWarn,
+			expectedNameLevelFound: true,
+		},
+		{
+			name:                   "most-specific-prefix",
+			action:                 func() { testError(test, nil, SetNameLogLevel("senzing.g2engine", LevelTrace)) },
+			expectedEngineLevel:    LevelTrace,
+			expectedConfigLevel:    LevelDebug,
+			expectedOtherLevel:     LevelWarn,
+			expectedUnnamedLevel:   LevelWarn,
+			expectedNameLevelFound: true,
+		},
+		{
+			name:                   "system-level-skips-overrides",
+			action:                 func() { testError(test, nil, SetLogLevel(LevelError)) },
+			expectedEngineLevel:    LevelTrace,
+			expectedConfigLevel:    LevelDebug,
+			expectedOtherLevel:     LevelError,
+			expectedUnnamedLevel:   LevelError,
+			expectedNameLevelFound: true,
+		},
+		{
+			name:                   "clear-to-parent-prefix",
+			action:                 func() { ClearNameLogLevel("senzing.g2engine") },
+			expectedEngineLevel:    LevelDebug,
+			expectedConfigLevel:    LevelDebug,
+			expectedOtherLevel:     LevelError,
+			expectedUnnamedLevel:   LevelError,
+			expectedNameLevelFound: true,
+		},
+		{
+			name:                   "clear-to-system-level",
+			action:                 func() { ClearNameLogLevel("senzing") },
+			expectedEngineLevel:    LevelError,
+			expectedConfigLevel:    LevelError,
+			expectedOtherLevel:     LevelError,
+			expectedUnnamedLevel:   LevelError,
+			expectedNameLevelFound: false,
+		},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			testCase.action()
+			assert.Equal(test, testCase.expectedEngineLevel, engineLogger.GetLogLevel())
+			assert.Equal(test, testCase.expectedConfigLevel, configLogger.GetLogLevel())
+			assert.Equal(test, testCase.expectedOtherLevel, otherLogger.GetLogLevel())
+			assert.Equal(test, testCase.expectedUnnamedLevel, unnamedLogger.GetLogLevel())
+			_, ok := GetNameLogLevel("senzing.g2engine.addRecord")
+			assert.Equal(test, testCase.expectedNameLevelFound, ok)
+		})
+	}
+	assert.Error(test, SetNameLogLevel("", LevelTrace))
+}
+
+func TestClearNameLogLevelRestoresNewLogLevel(test *testing.T) {
+	testRestoreSystemLogLevel(test)
+	lock.Lock()
+	isSystemLogLevelSet = false
+	lock.Unlock()
+	testObject, err := New(Name("senzing.loader"), logger.LevelDebug)
+	testError(test, testObject, err)
+	testError(test, testObject, SetNameLogLevel("senzing", LevelError))
+	assert.Equal(test, LevelError, testObject.GetLogLevel())
+	ClearNameLogLevel("senzing")
+	assert.Equal(test, LevelDebug, testObject.GetLogLevel())
+}
+
 // -- Test IsXxxx method ------------------------------------------------------
 
 func TestMessageLoggerNewIsMethods(test *testing.T) {
@@ -406,6 +1235,7 @@ func TestMessageLoggerNewSenzingLogger(test *testing.T) {
 				actual, err := testObject.Message(testCase.messageNumber, testCase.details...)
 				testError(test, testObject, err)
 				assert.Equal(test, testCase.expectedSenzing, actual, testCase.name)
+				assert.NoError(test, messageformat.ValidateSenzing(actual), testCase.name)
 			})
 		}
 	}
@@ -457,6 +1287,7 @@ func TestMessageLoggerNewSenzingApiLogger(test *testing.T) {
 				actual, err := testObject.Message(testCase.messageNumber, testCase.details...)
 				testError(test, testObject, err)
 				assert.Equal(test, testCase.expectedSenzing, actual, testCase.name)
+				assert.NoError(test, messageformat.ValidateSenzing(actual), testCase.name)
 			})
 		}
 	}
@@ -0,0 +1,123 @@
+/*
+The messagetrace package produces values for the "trace_id" and "span_id" fields
+from W3C trace context (https://www.w3.org/TR/trace-context/).
+
+The trace context of a message comes from its details:
+a TraceContext or a TraceParent value, such as the "traceparent" header of an HTTP request.
+The XxxxContext() methods of a message logger also add the TraceContext stored by NewContext().
+
+No collector or tracing library is needed.
+To use the spans of a tracing library, such as OpenTelemetry,
+register a context extractor that returns their identifiers as a TraceContext, e.g.
+
+	messagelogger.RegisterContextExtractor(func(ctx context.Context) []interface{} {
+		spanContext := trace.SpanContextFromContext(ctx)
+		if !spanContext.IsValid() {
+			return nil
+		}
+		return []interface{}{messagetrace.TraceContext{
+			TraceId: spanContext.TraceID().String(),
+			SpanId:  spanContext.SpanID().String(),
+		}}
+	})
+
+For examples of use, see https://github.com/Senzing/go-logging/blob/main/messagetrace/messagetrace_test.go
+*/
+package messagetrace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The MessageTraceInterface type defines methods for determining the trace context of a message.
+type MessageTraceInterface interface {
+	MessageTrace(messageNumber int, details ...interface{}) (TraceContext, error) // Get the "trace_id" and "span_id" values from the messageNumber and details.
+}
+
+// The TraceContext type identifies a span of a trace. It can be used in the details parameter.
+type TraceContext struct {
+	TraceId string // 32 lowercase hexadecimal digits.
+	SpanId  string // 16 lowercase hexadecimal digits.
+}
+
+// The TraceParent type is used to identify strings as W3C "traceparent" values in the details parameter,
+// e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+// A value that is not valid stays in the "details" field.
+type TraceParent string
+
+// The key of the TraceContext stored in a context.Context.
+type contextKey struct{}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return true if value is length lowercase hexadecimal digits.
+func isHex(value string, length int) bool {
+	if len(value) != length {
+		return false
+	}
+	for _, character := range value {
+		if (character < '0' || character > '9') && (character < 'a' || character > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if value is length lowercase hexadecimal digits, not all zero, as trace and span identifiers must be.
+func isId(value string, length int) bool {
+	return isHex(value, length) && strings.Trim(value, "0") != ""
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+// The FromContext function returns the TraceContext stored in ctx by NewContext().
+func FromContext(ctx context.Context) (TraceContext, bool) {
+	if ctx == nil {
+		return TraceContext{}, false
+	}
+	result, ok := ctx.Value(contextKey{}).(TraceContext)
+	return result, ok
+}
+
+// The NewContext function returns a copy of ctx holding traceContext.
+func NewContext(ctx context.Context, traceContext TraceContext) context.Context {
+	return context.WithValue(ctx, contextKey{}, traceContext)
+}
+
+/*
+The ParseTraceParent function returns the trace and span identifiers of a W3C "traceparent" value:
+version-traceid-parentid-flags.
+Versions after 00 may have more fields, which are ignored.
+*/
+func ParseTraceParent(traceParent string) (TraceContext, error) {
+	fields := strings.Split(strings.TrimSpace(traceParent), "-")
+	switch {
+	case len(fields) < 4, !isHex(fields[0], 2), fields[0] == "ff", fields[0] == "00" && len(fields) != 4:
+		return TraceContext{}, fmt.Errorf("invalid traceparent %q", traceParent)
+	case !isId(fields[1], 32), !isId(fields[2], 16), !isHex(fields[3], 2):
+		return TraceContext{}, fmt.Errorf("invalid traceparent %q", traceParent)
+	}
+	return TraceContext{
+		TraceId: fields[1],
+		SpanId:  fields[2],
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+// The IsValid method returns true if the trace and span identifiers are well formed and not all zero.
+func (traceContext TraceContext) IsValid() bool {
+	return isId(traceContext.TraceId, 32) && isId(traceContext.SpanId, 16)
+}
@@ -0,0 +1,36 @@
+/*
+The MessageTraceDefault implementation returns the trace context given in the details.
+*/
+package messagetrace
+
+import "fmt"
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The MessageTraceDefault type is for returning the trace context given in the details.
+type MessageTraceDefault struct{}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The MessageTrace method returns the last TraceContext or TraceParent in details.
+An invalid value is returned as an empty trace context and an error.
+*/
+func (messageTrace *MessageTraceDefault) MessageTrace(messageNumber int, details ...interface{}) (TraceContext, error) {
+	for index := len(details) - 1; index >= 0; index-- {
+		switch typedValue := details[index].(type) {
+		case TraceContext:
+			if !typedValue.IsValid() {
+				return TraceContext{}, fmt.Errorf("invalid trace context %+v", typedValue)
+			}
+			return typedValue, nil
+		case TraceParent:
+			return ParseTraceParent(string(typedValue))
+		}
+	}
+	return TraceContext{}, nil
+}
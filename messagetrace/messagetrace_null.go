@@ -0,0 +1,20 @@
+/*
+The MessageTraceNull implementation returns an empty trace context.
+*/
+package messagetrace
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The MessageTraceNull type is for returning an empty trace context.
+type MessageTraceNull struct{}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The MessageTrace method returns an empty trace context.
+func (messageTrace *MessageTraceNull) MessageTrace(messageNumber int, details ...interface{}) (TraceContext, error) {
+	return TraceContext{}, nil
+}
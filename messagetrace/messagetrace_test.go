@@ -0,0 +1,134 @@
+package messagetrace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	traceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+	spanId  = "00f067aa0ba902b7"
+)
+
+var testCases = []struct {
+	name            string
+	details         []interface{}
+	expectedDefault TraceContext
+	expectedErr     bool
+}{
+	{
+		name:    "messagetrace-01-none",
+		details: []interface{}{"A", 1},
+	},
+	{
+		name:            "messagetrace-02-traceparent",
+		details:         []interface{}{"A", TraceParent("00-" + traceId + "-" + spanId + "-01")},
+		expectedDefault: TraceContext{TraceId: traceId, SpanId: spanId},
+	},
+	{
+		name:            "messagetrace-03-trace-context",
+		details:         []interface{}{TraceContext{TraceId: traceId, SpanId: spanId}, "A"},
+		expectedDefault: TraceContext{TraceId: traceId, SpanId: spanId},
+	},
+	{
+		name:            "messagetrace-04-last-wins",
+		details:         []interface{}{TraceParent("00-" + traceId + "-" + spanId + "-01"), TraceContext{TraceId: traceId, SpanId: "1111111111111111"}},
+		expectedDefault: TraceContext{TraceId: traceId, SpanId: "1111111111111111"},
+	},
+	{
+		name:        "messagetrace-05-bad-traceparent",
+		details:     []interface{}{TraceParent("00-" + traceId + "-" + spanId)},
+		expectedErr: true,
+	},
+	{
+		name:        "messagetrace-06-bad-trace-context",
+		details:     []interface{}{TraceContext{TraceId: traceId}},
+		expectedErr: true,
+	},
+	{
+		name:    "messagetrace-07-string-is-not-traceparent",
+		details: []interface{}{"00-" + traceId + "-" + spanId + "-01"},
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+func testError(test *testing.T, testObject MessageTraceInterface, err error) {
+	if err != nil {
+		assert.Fail(test, err.Error())
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions - names begin with "Test"
+// ----------------------------------------------------------------------------
+
+func TestMessageTraceDefault(test *testing.T) {
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			testObject := &MessageTraceDefault{}
+			actual, err := testObject.MessageTrace(0, testCase.details...)
+			if testCase.expectedErr {
+				assert.Error(test, err, testCase.name)
+			} else {
+				testError(test, testObject, err)
+			}
+			assert.Equal(test, testCase.expectedDefault, actual, testCase.name)
+		})
+	}
+}
+
+func TestMessageTraceNull(test *testing.T) {
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			testObject := &MessageTraceNull{}
+			actual, err := testObject.MessageTrace(0, testCase.details...)
+			testError(test, testObject, err)
+			assert.Equal(test, TraceContext{}, actual, testCase.name)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestParseTraceParent(test *testing.T) {
+	for _, traceParent := range []string{
+		"00-" + traceId + "-" + spanId + "-01",
+		"00-" + traceId + "-" + spanId + "-00\n",
+		"cc-" + traceId + "-" + spanId + "-01-future",
+	} {
+		actual, err := ParseTraceParent(traceParent)
+		assert.NoError(test, err, traceParent)
+		assert.Equal(test, TraceContext{TraceId: traceId, SpanId: spanId}, actual, traceParent)
+	}
+	for _, traceParent := range []string{
+		"",
+		"00-" + traceId + "-" + spanId + "-01-extra",
+		"ff-" + traceId + "-" + spanId + "-01",
+		"0-" + traceId + "-" + spanId + "-01",
+		"00-00000000000000000000000000000000-" + spanId + "-01",
+		"00-" + traceId + "-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-" + spanId + "-01",
+		"00-" + traceId + "-" + spanId + "-1",
+	} {
+		_, err := ParseTraceParent(traceParent)
+		assert.Error(test, err, traceParent)
+	}
+}
+
+func TestContext(test *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(test, ok)
+	expected := TraceContext{TraceId: traceId, SpanId: spanId}
+	actual, ok := FromContext(NewContext(context.Background(), expected))
+	assert.True(test, ok)
+	assert.Equal(test, expected, actual)
+	assert.True(test, actual.IsValid())
+	assert.False(test, TraceContext{}.IsValid())
+}
@@ -0,0 +1,444 @@
+/*
+The otlpsink package exports messages to an OpenTelemetry collector as log records over OTLP/HTTP.
+
+Messages are formatted by messageformat.MessageFormatOtlp.
+The Writer sends them in batches, using the JSON encoding of OTLP, so no protobuf code is needed.
+A failed request is retried with exponential backoff if the collector is unreachable or busy.
+Records waiting to be sent are held in a buffer of BufferSize records; when it is full, the oldest record is dropped.
+
+	writer, err := otlpsink.New("http://localhost:4318/v1/logs")
+	if err != nil {
+		...
+	}
+	defer writer.Close()
+	writer.Resource = map[string]string{"service.name": "loader"}
+	messageLogger, _ := messagelogger.New(messagelogger.NewWriterSink(writer, &messageformat.MessageFormatOtlp{}))
+
+Fields of the Writer must be set before the first Write().
+
+For examples of use, see https://github.com/Senzing/go-logging/blob/main/otlpsink/otlpsink_test.go
+*/
+package otlpsink
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The Writer type queues each Write(), one OTLP log record, and sends the records to a collector in batches.
+A batch is sent when BatchSize records are waiting, every FlushInterval, and by Flush() and Close().
+Errors from sending are returned by Flush() and Close().
+*/
+type Writer struct {
+	Endpoint       string            // URL of the OTLP/HTTP logs endpoint, e.g. http://localhost:4318/v1/logs.
+	Headers        map[string]string // Added to each request, e.g. an authorization header.
+	Resource       map[string]string // Resource attributes. If "service.name" is missing, "unknown_service:" and the program name is used.
+	Client         *http.Client      // If nil, a client with a DefaultTimeout timeout is used.
+	BatchSize      int               // Most records in a request. If 0, DefaultBatchSize is used.
+	BufferSize     int               // Most records waiting to be sent. If 0, DefaultBufferSize is used.
+	FlushInterval  time.Duration     // Longest wait before a partial batch is sent. If 0, DefaultFlushInterval is used.
+	MaxRetries     int               // Retries of a failed request. If 0, DefaultMaxRetries is used; if negative, none.
+	InitialBackoff time.Duration     // Wait before the first retry, doubled for each retry. If 0, DefaultInitialBackoff is used.
+	MaxBackoff     time.Duration     // Longest wait before a retry. If 0, DefaultMaxBackoff is used.
+	once           sync.Once
+	mutex          sync.Mutex
+	idle           *sync.Cond
+	records        []json.RawMessage
+	isExporting    bool
+	isFlushing     bool
+	isClosed       bool
+	dropped        uint64
+	errorList      []error
+	wake           chan struct{}
+	closing        chan struct{}
+	done           chan struct{}
+}
+
+// The body of an OTLP/HTTP logs request: ExportLogsServiceRequest.
+type exportLogsRequest struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+type resourceLogs struct {
+	Resource  resource    `json:"resource"`
+	ScopeLogs []scopeLogs `json:"scopeLogs"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type keyValue struct {
+	Key   string      `json:"key"`
+	Value stringValue `json:"value"`
+}
+
+type stringValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type scopeLogs struct {
+	Scope      scope             `json:"scope"`
+	LogRecords []json.RawMessage `json:"logRecords"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+// The body of an OTLP/HTTP logs response: ExportLogsServiceResponse.
+type exportLogsResponse struct {
+	PartialSuccess struct {
+		RejectedLogRecords json.Number `json:"rejectedLogRecords"`
+		ErrorMessage       string      `json:"errorMessage"`
+	} `json:"partialSuccess"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// DefaultXxxx values are used when the matching Writer field is zero.
+const (
+	DefaultBatchSize      = 512
+	DefaultBufferSize     = 2048
+	DefaultFlushInterval  = 5 * time.Second
+	DefaultInitialBackoff = time.Second
+	DefaultMaxBackoff     = 30 * time.Second
+	DefaultMaxRetries     = 5
+	DefaultTimeout        = 10 * time.Second
+)
+
+// The instrumentation scope of the log records.
+const ScopeName = "github.com/senzing/go-logging"
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+// The New function returns a Writer for an OTLP/HTTP logs endpoint, e.g. http://localhost:4318/v1/logs.
+func New(endpoint string) (*Writer, error) {
+	endpointUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if (endpointUrl.Scheme != "http" && endpointUrl.Scheme != "https") || len(endpointUrl.Host) == 0 {
+		return nil, fmt.Errorf("unsupported OTLP endpoint %q", endpoint)
+	}
+	return &Writer{
+		Endpoint: endpoint,
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Return the wait requested by a Retry-After header in seconds, or 0.
+func retryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Apply default values and start the background exporter.
+func (writer *Writer) start() {
+	writer.once.Do(func() {
+		if writer.Client == nil {
+			writer.Client = &http.Client{Timeout: DefaultTimeout}
+		}
+		if writer.BatchSize <= 0 {
+			writer.BatchSize = DefaultBatchSize
+		}
+		if writer.BufferSize <= 0 {
+			writer.BufferSize = DefaultBufferSize
+		}
+		if writer.FlushInterval <= 0 {
+			writer.FlushInterval = DefaultFlushInterval
+		}
+		if writer.MaxRetries == 0 {
+			writer.MaxRetries = DefaultMaxRetries
+		}
+		if writer.InitialBackoff <= 0 {
+			writer.InitialBackoff = DefaultInitialBackoff
+		}
+		if writer.MaxBackoff <= 0 {
+			writer.MaxBackoff = DefaultMaxBackoff
+		}
+		writer.idle = sync.NewCond(&writer.mutex)
+		writer.wake = make(chan struct{}, 1)
+		writer.closing = make(chan struct{})
+		writer.done = make(chan struct{})
+		go writer.run()
+	})
+}
+
+// Wake the background exporter without waiting.
+func (writer *Writer) signal() {
+	select {
+	case writer.wake <- struct{}{}:
+	default:
+	}
+}
+
+// The run method sends batches until the writer is closed.
+func (writer *Writer) run() {
+	defer close(writer.done)
+	ticker := time.NewTicker(writer.FlushInterval)
+	defer ticker.Stop()
+	for {
+		isAll := false
+		select {
+		case <-writer.wake:
+		case <-ticker.C:
+			isAll = true
+		case <-writer.closing:
+		}
+		if writer.exportRecords(isAll) {
+			return
+		}
+	}
+}
+
+/*
+The exportRecords method sends the full batches, or all records if isAll is true
+or a flush or close is waiting. It returns true if the writer is closed.
+*/
+func (writer *Writer) exportRecords(isAll bool) bool {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	for {
+		isAll = isAll || writer.isFlushing || writer.isClosed
+		if len(writer.records) == 0 || (!isAll && len(writer.records) < writer.BatchSize) {
+			writer.isFlushing = false
+			writer.idle.Broadcast()
+			return writer.isClosed
+		}
+		size := len(writer.records)
+		if size > writer.BatchSize {
+			size = writer.BatchSize
+		}
+		batch := append([]json.RawMessage{}, writer.records[:size]...)
+		clear(writer.records[:size])
+		writer.records = writer.records[size:]
+		writer.isExporting = true
+		writer.mutex.Unlock()
+
+		err := writer.export(batch)
+
+		writer.mutex.Lock()
+		writer.isExporting = false
+		if err != nil {
+			writer.errorList = append(writer.errorList, fmt.Errorf("dropped %d OTLP log records: %w", len(batch), err))
+		}
+	}
+}
+
+// The export method sends a batch, retrying with exponential backoff.
+func (writer *Writer) export(batch []json.RawMessage) error {
+	body, err := writer.requestBody(batch)
+	if err != nil {
+		return err
+	}
+	backoff := writer.InitialBackoff
+	for retry := 0; ; retry++ {
+		isRetryable, wait, err := writer.post(body)
+		if err == nil || !isRetryable || retry >= writer.MaxRetries {
+			return err
+		}
+		if wait <= 0 {
+			wait = backoff
+		}
+		if wait > writer.MaxBackoff {
+			wait = writer.MaxBackoff
+		}
+		if !writer.sleep(wait) {
+			return err
+		}
+		backoff *= 2
+		if backoff > writer.MaxBackoff {
+			backoff = writer.MaxBackoff
+		}
+	}
+}
+
+/*
+The post method sends one request.
+It returns true if the request may be retried, with the wait requested by the collector.
+*/
+func (writer *Writer) post(body []byte) (bool, time.Duration, error) {
+	request, err := http.NewRequest(http.MethodPost, writer.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range writer.Headers {
+		request.Header.Set(key, value)
+	}
+	response, err := writer.Client.Do(request)
+	if err != nil {
+		return true, 0, err
+	}
+	defer response.Body.Close()
+	responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+
+	switch {
+	case response.StatusCode >= 200 && response.StatusCode < 300:
+		var exportResponse exportLogsResponse
+		if json.Unmarshal(responseBody, &exportResponse) == nil {
+			if rejected, _ := exportResponse.PartialSuccess.RejectedLogRecords.Int64(); rejected > 0 {
+				return false, 0, fmt.Errorf("OTLP collector rejected %d log records: %s", rejected, exportResponse.PartialSuccess.ErrorMessage)
+			}
+		}
+		return false, 0, nil
+	case response.StatusCode == http.StatusTooManyRequests, response.StatusCode == http.StatusBadGateway,
+		response.StatusCode == http.StatusServiceUnavailable, response.StatusCode == http.StatusGatewayTimeout:
+		return true, retryAfter(response.Header.Get("Retry-After")), fmt.Errorf("OTLP export to %s failed: %s", writer.Endpoint, response.Status)
+	}
+	return false, 0, fmt.Errorf("OTLP export to %s failed: %s %s", writer.Endpoint, response.Status, bytes.TrimSpace(responseBody))
+}
+
+// Return the ExportLogsServiceRequest for a batch of log records.
+func (writer *Writer) requestBody(batch []json.RawMessage) ([]byte, error) {
+	attributes := map[string]string{
+		"service.name": "unknown_service:" + filepath.Base(os.Args[0]),
+	}
+	for key, value := range writer.Resource {
+		attributes[key] = value
+	}
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	resourceAttributes := make([]keyValue, 0, len(keys))
+	for _, key := range keys {
+		resourceAttributes = append(resourceAttributes, keyValue{Key: key, Value: stringValue{StringValue: attributes[key]}})
+	}
+	return json.Marshal(&exportLogsRequest{
+		ResourceLogs: []resourceLogs{{
+			Resource: resource{Attributes: resourceAttributes},
+			ScopeLogs: []scopeLogs{{
+				Scope:      scope{Name: ScopeName},
+				LogRecords: batch,
+			}},
+		}},
+	})
+}
+
+// Wait before a retry. Returns false if the writer was closed while waiting.
+func (writer *Writer) sleep(wait time.Duration) bool {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-writer.closing:
+		return false
+	}
+}
+
+// Return the errors seen since they were last returned.  The caller holds the mutex.
+func (writer *Writer) takeErrors() error {
+	err := errors.Join(writer.errorList...)
+	writer.errorList = nil
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Write method queues data, an OTLP log record in JSON, for sending.
+func (writer *Writer) Write(data []byte) (int, error) {
+	record := bytes.TrimSuffix(data, []byte("\n"))
+	if len(record) == 0 {
+		return len(data), nil
+	}
+	if !json.Valid(record) {
+		return 0, fmt.Errorf("OTLP log record is not JSON: %q", record)
+	}
+	writer.start()
+	writer.mutex.Lock()
+	if writer.isClosed {
+		writer.mutex.Unlock()
+		return 0, errors.New("OTLP writer is closed")
+	}
+	if len(writer.records) >= writer.BufferSize {
+		writer.records[0] = nil
+		writer.records = writer.records[1:]
+		writer.dropped++
+	}
+	writer.records = append(writer.records, append(json.RawMessage{}, record...))
+	isFull := len(writer.records) >= writer.BatchSize
+	writer.mutex.Unlock()
+	if isFull {
+		writer.signal()
+	}
+	return len(data), nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+// The Close method sends the waiting records and stops the background exporter.
+// Failed requests are not retried after Close() is called.
+func (writer *Writer) Close() error {
+	writer.start()
+	writer.mutex.Lock()
+	if !writer.isClosed {
+		writer.isClosed = true
+		close(writer.closing)
+	}
+	writer.mutex.Unlock()
+	<-writer.done
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	return writer.takeErrors()
+}
+
+// The Dropped method returns the number of records discarded because the buffer was full.
+func (writer *Writer) Dropped() uint64 {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	return writer.dropped
+}
+
+// The Flush method sends the waiting records and returns the errors seen since the last Flush() or Close().
+func (writer *Writer) Flush() error {
+	writer.start()
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	if !writer.isClosed && (len(writer.records) > 0 || writer.isExporting) {
+		writer.isFlushing = true
+		writer.signal()
+		for len(writer.records) > 0 || writer.isExporting {
+			writer.idle.Wait()
+		}
+	}
+	return writer.takeErrors()
+}
@@ -0,0 +1,260 @@
+package otlpsink
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing/go-logging/logger"
+	"github.com/senzing/go-logging/messageformat"
+	"github.com/senzing/go-logging/messagelogger"
+	"github.com/stretchr/testify/assert"
+)
+
+// A stand-in for an OpenTelemetry collector.
+type testCollector struct {
+	mutex    sync.Mutex
+	requests []exportLogsRequest
+	headers  []http.Header
+	statuses []int // Status of each response; after the last, 200.
+	calls    int
+	server   *httptest.Server
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+func testError(test *testing.T, err error) {
+	if err != nil {
+		assert.Fail(test, err.Error())
+	}
+}
+
+func newTestCollector(test *testing.T, statuses ...int) *testCollector {
+	result := &testCollector{statuses: statuses}
+	result.server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		testError(test, err)
+		result.mutex.Lock()
+		defer result.mutex.Unlock()
+		status := http.StatusOK
+		if result.calls < len(result.statuses) {
+			status = result.statuses[result.calls]
+		}
+		result.calls++
+		if status != http.StatusOK {
+			response.WriteHeader(status)
+			return
+		}
+		var exportRequest exportLogsRequest
+		testError(test, json.Unmarshal(body, &exportRequest))
+		result.requests = append(result.requests, exportRequest)
+		result.headers = append(result.headers, request.Header)
+		response.Header().Set("Content-Type", "application/json")
+		_, _ = response.Write([]byte(`{}`))
+	}))
+	test.Cleanup(result.server.Close)
+	return result
+}
+
+func newTestWriter(test *testing.T, collector *testCollector) *Writer {
+	writer, err := New(collector.server.URL + "/v1/logs")
+	testError(test, err)
+	writer.FlushInterval = time.Hour
+	writer.InitialBackoff = time.Millisecond
+	writer.MaxBackoff = 5 * time.Millisecond
+	return writer
+}
+
+// Return the number of log records in each request.
+func (collector *testCollector) batchSizes() []int {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	result := []int{}
+	for _, request := range collector.requests {
+		result = append(result, len(request.ResourceLogs[0].ScopeLogs[0].LogRecords))
+	}
+	return result
+}
+
+// Return the log records of every request.
+func (collector *testCollector) logRecords(test *testing.T) []map[string]interface{} {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	result := []map[string]interface{}{}
+	for _, request := range collector.requests {
+		for _, record := range request.ResourceLogs[0].ScopeLogs[0].LogRecords {
+			var logRecord map[string]interface{}
+			testError(test, json.Unmarshal(record, &logRecord))
+			result = append(result, logRecord)
+		}
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestWriter(test *testing.T) {
+	collector := newTestCollector(test)
+	writer := newTestWriter(test, collector)
+	writer.Headers = map[string]string{"Authorization": "Bearer token-1"}
+	writer.Resource = map[string]string{"service.name": "loader", "host.name": "host-1"}
+	messageLogger, err := messagelogger.New(messagelogger.NewWriterSink(writer, &messageformat.MessageFormatOtlp{}))
+	testError(test, err)
+	testError(test, messageLogger.Log(3001, "Bob", logger.LevelWarn))
+	testError(test, messageLogger.Log(2001, "Jane"))
+	testError(test, writer.Flush())
+
+	assert.Equal(test, []int{2}, collector.batchSizes())
+	request := collector.requests[0]
+	assert.Equal(test, "application/json", collector.headers[0].Get("Content-Type"))
+	assert.Equal(test, "Bearer token-1", collector.headers[0].Get("Authorization"))
+	assert.Equal(test, []keyValue{
+		{Key: "host.name", Value: stringValue{StringValue: "host-1"}},
+		{Key: "service.name", Value: stringValue{StringValue: "loader"}},
+	}, request.ResourceLogs[0].Resource.Attributes)
+	assert.Equal(test, ScopeName, request.ResourceLogs[0].ScopeLogs[0].Scope.Name)
+
+	logRecords := collector.logRecords(test)
+	assert.Equal(test, float64(messageformat.OtlpSeverityNumberWarn), logRecords[0]["severityNumber"])
+	assert.Equal(test, "WARN", logRecords[0]["severityText"])
+	assert.Equal(test, []interface{}{
+		map[string]interface{}{"key": "senzing.id", "value": map[string]interface{}{"stringValue": "3001"}},
+		map[string]interface{}{"key": "senzing.details.1", "value": map[string]interface{}{"stringValue": "Bob"}},
+	}, logRecords[0]["attributes"].([]interface{})[:2])
+	assert.Equal(test, float64(messageformat.OtlpSeverityNumberInfo), logRecords[1]["severityNumber"])
+
+	testError(test, writer.Close())
+	_, err = writer.Write([]byte("{}\n"))
+	assert.Error(test, err)
+}
+
+func TestWriterBatching(test *testing.T) {
+	collector := newTestCollector(test)
+	writer := newTestWriter(test, collector)
+	writer.BatchSize = 2
+	for index := 0; index < 5; index++ {
+		_, err := writer.Write([]byte("{}\n"))
+		testError(test, err)
+	}
+	testError(test, writer.Flush())
+	assert.Equal(test, []int{2, 2, 1}, collector.batchSizes())
+	testError(test, writer.Flush())
+	assert.Equal(test, []int{2, 2, 1}, collector.batchSizes())
+}
+
+func TestWriterFlushInterval(test *testing.T) {
+	collector := newTestCollector(test)
+	writer := newTestWriter(test, collector)
+	writer.FlushInterval = 10 * time.Millisecond
+	_, err := writer.Write([]byte("{}"))
+	testError(test, err)
+	assert.Eventually(test, func() bool { return len(collector.batchSizes()) == 1 }, 5*time.Second, 5*time.Millisecond)
+	testError(test, writer.Close())
+}
+
+func TestWriterBuffer(test *testing.T) {
+	collector := newTestCollector(test)
+	writer := newTestWriter(test, collector)
+	writer.BufferSize = 2
+	for _, record := range []string{`{"body":{"intValue":"1"}}`, `{"body":{"intValue":"2"}}`, `{"body":{"intValue":"3"}}`} {
+		_, err := writer.Write([]byte(record))
+		testError(test, err)
+	}
+	assert.Equal(test, uint64(1), writer.Dropped())
+	testError(test, writer.Close())
+	logRecords := collector.logRecords(test)
+	if assert.Len(test, logRecords, 2) {
+		assert.Equal(test, map[string]interface{}{"intValue": "2"}, logRecords[0]["body"])
+		assert.Equal(test, map[string]interface{}{"intValue": "3"}, logRecords[1]["body"])
+	}
+}
+
+func TestWriterRetry(test *testing.T) {
+	collector := newTestCollector(test, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	writer := newTestWriter(test, collector)
+	_, err := writer.Write([]byte("{}"))
+	testError(test, err)
+	testError(test, writer.Flush())
+	assert.Equal(test, 3, collector.calls)
+	assert.Equal(test, []int{1}, collector.batchSizes())
+}
+
+func TestWriterRetriesExhausted(test *testing.T) {
+	collector := newTestCollector(test, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	writer := newTestWriter(test, collector)
+	writer.MaxRetries = 2
+	_, err := writer.Write([]byte("{}"))
+	testError(test, err)
+	err = writer.Flush()
+	assert.ErrorContains(test, err, "dropped 1 OTLP log records")
+	assert.ErrorContains(test, err, "502 Bad Gateway")
+	assert.Equal(test, 3, collector.calls)
+	testError(test, writer.Flush())
+}
+
+func TestWriterNotRetryable(test *testing.T) {
+	collector := newTestCollector(test, http.StatusBadRequest)
+	writer := newTestWriter(test, collector)
+	_, err := writer.Write([]byte("{}"))
+	testError(test, err)
+	assert.ErrorContains(test, writer.Flush(), "400 Bad Request")
+	assert.Equal(test, 1, collector.calls)
+}
+
+func TestWriterPartialSuccess(test *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		_, _ = response.Write([]byte(`{"partialSuccess":{"rejectedLogRecords":"1","errorMessage":"bad record"}}`))
+	}))
+	defer server.Close()
+	writer, err := New(server.URL)
+	testError(test, err)
+	_, err = writer.Write([]byte("{}"))
+	testError(test, err)
+	assert.ErrorContains(test, writer.Flush(), "OTLP collector rejected 1 log records: bad record")
+}
+
+func TestWriterUnreachable(test *testing.T) {
+	collector := newTestCollector(test)
+	writer := newTestWriter(test, collector)
+	collector.server.Close()
+	writer.MaxRetries = -1
+	_, err := writer.Write([]byte("{}"))
+	testError(test, err)
+	assert.Error(test, writer.Close())
+}
+
+func TestWriterNotJson(test *testing.T) {
+	writer, err := New("http://localhost:4318/v1/logs")
+	testError(test, err)
+	_, err = writer.Write([]byte("not JSON"))
+	assert.Error(test, err)
+	n, err := writer.Write([]byte("\n"))
+	testError(test, err)
+	assert.Equal(test, 1, n)
+	testError(test, writer.Close())
+}
+
+// ----------------------------------------------------------------------------
+// Test constructors
+// ----------------------------------------------------------------------------
+
+func TestNew(test *testing.T) {
+	for _, endpoint := range []string{"", "localhost:4318", "ftp://localhost/v1/logs", "http://%zz"} {
+		_, err := New(endpoint)
+		assert.Error(test, err, endpoint)
+	}
+}
+
+func TestRetryAfter(test *testing.T) {
+	assert.Equal(test, 2*time.Second, retryAfter("2"))
+	assert.Equal(test, time.Duration(0), retryAfter("Wed, 21 Oct 2015 07:28:00 GMT"))
+	assert.Equal(test, time.Duration(0), retryAfter(""))
+}
@@ -0,0 +1,103 @@
+/*
+The reconnect package holds the connection shared by the writers of syslogsink, journaldsink, and gelfsink.
+
+A Conn is opened on first use.
+If sending fails, the connection is opened again and the message is sent once more,
+so a restarted daemon or collector does not lose more than the message in flight.
+
+	var conn reconnect.Conn
+	dial := func() (net.Conn, error) { return net.Dial("udp", "localhost:514") }
+	err := conn.Send(dial, func(netConn net.Conn) error {
+		_, err := netConn.Write(message)
+		return err
+	})
+
+For examples of use, see https://github.com/Senzing/go-logging/blob/main/reconnect/reconnect_test.go
+*/
+package reconnect
+
+import (
+	"net"
+	"sync"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The Conn type is a net.Conn that is opened again when sending fails. It is safe for concurrent use.
+type Conn struct {
+	mutex sync.Mutex
+	conn  net.Conn
+}
+
+// The DialFunc type opens a connection.
+type DialFunc func() (net.Conn, error)
+
+// The SendFunc type sends one message over an open connection.
+type SendFunc func(conn net.Conn) error
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Open the connection if it is not open.  The caller holds the mutex.
+func (conn *Conn) connect(dial DialFunc) error {
+	if conn.conn != nil {
+		return nil
+	}
+	netConn, err := dial()
+	if err != nil {
+		return err
+	}
+	conn.conn = netConn
+	return nil
+}
+
+// Close the connection if it is open.  The caller holds the mutex.
+func (conn *Conn) close() error {
+	if conn.conn == nil {
+		return nil
+	}
+	err := conn.conn.Close()
+	conn.conn = nil
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+// The Close method closes the connection. A later Send() opens it again.
+func (conn *Conn) Close() error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	return conn.close()
+}
+
+// The Connect method opens the connection with dial, if it is not open.
+func (conn *Conn) Connect(dial DialFunc) error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	return conn.connect(dial)
+}
+
+/*
+The Send method calls send with the connection, opening it with dial if needed.
+If send fails, the connection is closed, opened again, and send is called once more.
+Calls are serialized, so send can write a message in several parts.
+*/
+func (conn *Conn) Send(dial DialFunc, send SendFunc) error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	if err := conn.connect(dial); err == nil {
+		if err := send(conn.conn); err == nil {
+			return nil
+		}
+		_ = conn.close()
+	}
+	if err := conn.connect(dial); err != nil {
+		return err
+	}
+	return send(conn.conn)
+}
@@ -0,0 +1,80 @@
+package reconnect
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+func testError(test *testing.T, err error) {
+	if err != nil {
+		assert.Fail(test, err.Error())
+	}
+}
+
+// Return a dial function that opens one end of a net.Pipe and counts the connections.
+func testDial(dials *int) DialFunc {
+	return func() (net.Conn, error) {
+		*dials++
+		client, server := net.Pipe()
+		server.Close()
+		return client, nil
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test public methods
+// ----------------------------------------------------------------------------
+
+func TestConnSend(test *testing.T) {
+	var conn Conn
+	dials := 0
+	sends := 0
+	send := func(netConn net.Conn) error {
+		sends++
+		return nil
+	}
+	testError(test, conn.Send(testDial(&dials), send))
+	testError(test, conn.Send(testDial(&dials), send))
+	assert.Equal(test, 1, dials)
+	assert.Equal(test, 2, sends)
+	testError(test, conn.Close())
+	testError(test, conn.Close())
+	testError(test, conn.Send(testDial(&dials), send))
+	assert.Equal(test, 2, dials)
+}
+
+func TestConnSendRetry(test *testing.T) {
+	var conn Conn
+	dials := 0
+	var sent []net.Conn
+	err := conn.Send(testDial(&dials), func(netConn net.Conn) error {
+		sent = append(sent, netConn)
+		if len(sent) == 1 {
+			return errors.New("broken pipe")
+		}
+		return nil
+	})
+	testError(test, err)
+	assert.Equal(test, 2, dials)
+	if assert.Len(test, sent, 2) {
+		assert.NotSame(test, sent[0], sent[1])
+	}
+
+	err = conn.Send(testDial(&dials), func(netConn net.Conn) error { return errors.New("broken pipe") })
+	assert.EqualError(test, err, "broken pipe")
+}
+
+func TestConnDialError(test *testing.T) {
+	var conn Conn
+	dialErr := errors.New("connection refused")
+	dial := func() (net.Conn, error) { return nil, dialErr }
+	assert.ErrorIs(test, conn.Connect(dial), dialErr)
+	assert.ErrorIs(test, conn.Send(dial, func(netConn net.Conn) error { return nil }), dialErr)
+}
@@ -0,0 +1,130 @@
+/*
+The syslogsink package sends messages to a syslog daemon over a unix datagram socket, UDP, or TCP.
+
+Messages are formatted by messageformat.MessageFormatSyslog (RFC 5424).
+Over TCP, each message is framed by octet counting (RFC 6587 section 3.4.1).
+
+	writer, err := syslogsink.Dial("udp", "localhost:514")
+	if err != nil {
+		...
+	}
+	defer writer.Close()
+	messageLogger, _ := messagelogger.New(messagelogger.NewWriterSink(writer, &messageformat.MessageFormatSyslog{AppName: "loader"}))
+
+For examples of use, see https://github.com/Senzing/go-logging/blob/main/syslogsink/syslogsink_test.go
+*/
+package syslogsink
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/senzing/go-logging/reconnect"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The Writer type sends each Write() to a syslog daemon as one message.
+A trailing newline, as added by Go's log package, is removed.
+If sending fails, the connection is opened again and the message is sent once more.
+*/
+type Writer struct {
+	Network string // "unixgram", "udp", or "tcp".
+	Address string // Socket path or host:port.
+	conn    reconnect.Conn
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// The sockets tried, in order, when the "unixgram" network is used without an address.
+var UnixSocketPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+/*
+The Dial function connects to a syslog daemon.
+The network is "unixgram", "udp", or "tcp".
+For "unixgram", an empty address selects the first of UnixSocketPaths that accepts a connection.
+*/
+func Dial(network string, address string) (*Writer, error) {
+	switch network {
+	case "unixgram", "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("unsupported syslog network %q", network)
+	}
+	result := &Writer{
+		Network: network,
+		Address: address,
+	}
+	if err := result.conn.Connect(result.dial); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Open the connection.
+func (writer *Writer) dial() (net.Conn, error) {
+	if writer.Network == "unixgram" && len(writer.Address) == 0 {
+		var errorList []error
+		for _, path := range UnixSocketPaths {
+			conn, err := net.Dial(writer.Network, path)
+			if err == nil {
+				return conn, nil
+			}
+			errorList = append(errorList, err)
+		}
+		return nil, errors.Join(errorList...)
+	}
+	return net.Dial(writer.Network, writer.Address)
+}
+
+// Return true if messages need framing.
+func (writer *Writer) isStream() bool {
+	switch writer.Network {
+	case "tcp", "tcp4", "tcp6":
+		return true
+	}
+	return false
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// The Write method sends data as one syslog message.
+func (writer *Writer) Write(data []byte) (int, error) {
+	message := bytes.TrimSuffix(data, []byte("\n"))
+	if len(message) == 0 {
+		return len(data), nil
+	}
+	if writer.isStream() {
+		message = append([]byte(strconv.Itoa(len(message))+" "), message...)
+	}
+	err := writer.conn.Send(writer.dial, func(conn net.Conn) error {
+		_, err := conn.Write(message)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// The Close method closes the connection.
+func (writer *Writer) Close() error {
+	return writer.conn.Close()
+}
@@ -0,0 +1,132 @@
+package syslogsink
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senzing/go-logging/logger"
+	"github.com/senzing/go-logging/messageformat"
+	"github.com/senzing/go-logging/messagelogger"
+	"github.com/stretchr/testify/assert"
+)
+
+var messageFormat = &messageformat.MessageFormatSyslog{
+	Hostname: "host",
+	AppName:  "app",
+	ProcId:   "1234",
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+func testError(test *testing.T, err error) {
+	if err != nil {
+		assert.Fail(test, err.Error())
+	}
+}
+
+// Log a WARN and an INFO message through a message logger sending to writer.
+func testLog(test *testing.T, writer *Writer) {
+	messageLogger, err := messagelogger.New(messagelogger.NewWriterSink(writer, messageFormat))
+	testError(test, err)
+	testError(test, messageLogger.Log(3001, "Bob", logger.LevelWarn))
+	testError(test, messageLogger.Log(2001, "Jane"))
+}
+
+func testAssertMessages(test *testing.T, messages []string) {
+	if assert.Len(test, messages, 2) {
+		assert.True(test, strings.HasPrefix(messages[0], "<12>1 "), messages[0])
+		assert.True(test, strings.HasSuffix(messages[0], ` host app 1234 3001 [details@32473 1="Bob" 2="3"]`), messages[0])
+		assert.True(test, strings.HasPrefix(messages[1], "<14>1 "), messages[1])
+		assert.True(test, strings.HasSuffix(messages[1], ` host app 1234 2001 [details@32473 1="Jane"]`), messages[1])
+	}
+}
+
+func testReadPackets(test *testing.T, conn net.PacketConn, count int) []string {
+	var result []string
+	buffer := make([]byte, 65536)
+	for len(result) < count {
+		length, _, err := conn.ReadFrom(buffer)
+		if err != nil {
+			assert.Fail(test, err.Error())
+			break
+		}
+		result = append(result, string(buffer[:length]))
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestDialUnsupported(test *testing.T) {
+	_, err := Dial("http", "localhost:80")
+	assert.Error(test, err)
+}
+
+func TestWriterUdp(test *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	testError(test, err)
+	defer listener.Close()
+	writer, err := Dial("udp", listener.LocalAddr().String())
+	testError(test, err)
+	defer writer.Close()
+	testLog(test, writer)
+	testAssertMessages(test, testReadPackets(test, listener, 2))
+}
+
+func TestWriterUnixgram(test *testing.T) {
+	socketPath := filepath.Join(test.TempDir(), "log")
+	listener, err := net.ListenPacket("unixgram", socketPath)
+	testError(test, err)
+	defer listener.Close()
+	writer, err := Dial("unixgram", socketPath)
+	testError(test, err)
+	defer writer.Close()
+	testLog(test, writer)
+	testAssertMessages(test, testReadPackets(test, listener, 2))
+}
+
+func TestWriterTcp(test *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	testError(test, err)
+	defer listener.Close()
+	received := make(chan []string)
+	go func() {
+		var messages []string
+		conn, err := listener.Accept()
+		if err != nil {
+			received <- messages
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for len(messages) < 2 {
+			var length int
+			if _, err := fmt.Fscan(reader, &length); err != nil {
+				break
+			}
+			if space, err := reader.ReadByte(); err != nil || space != ' ' {
+				break
+			}
+			message := make([]byte, length)
+			if _, err := io.ReadFull(reader, message); err != nil {
+				break
+			}
+			messages = append(messages, string(message))
+		}
+		received <- messages
+	}()
+	writer, err := Dial("tcp", listener.Addr().String())
+	testError(test, err)
+	defer writer.Close()
+	testLog(test, writer)
+	testAssertMessages(test, <-received)
+}